@@ -89,10 +89,13 @@ var decodedTypes = []reflect.Type{
 	reflect.TypeOf(mail.Address{}),
 	reflect.TypeOf(regexp.Regexp{}),
 	reflect.TypeOf(url.URL{}),
+	reflect.TypeOf(schema.RedirectTarget{}),
+	reflect.TypeOf(schema.URLWithUserInfo{}),
 	reflect.TypeOf(time.Duration(0)),
 	reflect.TypeOf(schema.Address{}),
 	reflect.TypeOf(schema.AddressTCP{}),
 	reflect.TypeOf(schema.AddressUDP{}),
+	reflect.TypeOf(schema.AddressQUIC{}),
 	reflect.TypeOf(schema.AddressLDAP{}),
 	reflect.TypeOf(schema.AddressSMTP{}),
 	reflect.TypeOf(schema.X509CertificateChain{}),
@@ -15,7 +15,6 @@ import (
 
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
 	"github.com/authelia/authelia/v4/internal/logging"
-	"github.com/authelia/authelia/v4/internal/utils"
 )
 
 // LDAPClientFactory an interface describing factories that produce LDAPConnection implementations.
@@ -32,7 +31,7 @@ func NewStandardLDAPClientFactory(config *schema.AuthenticationBackendLDAP, cert
 		dialer = &LDAPClientDialerStandard{}
 	}
 
-	tlsc := utils.NewTLSConfig(config.TLS, certs)
+	tlsc := schema.NewTLSConfig(config.TLS, certs)
 
 	opts := []ldap.DialOpt{
 		ldap.DialWithDialer(&net.Dialer{Timeout: config.Timeout}),
@@ -83,7 +82,7 @@ func NewPooledLDAPClientFactory(config *schema.AuthenticationBackendLDAP, certs
 		dialer = &LDAPClientDialerStandard{}
 	}
 
-	tlsc := utils.NewTLSConfig(config.TLS, certs)
+	tlsc := schema.NewTLSConfig(config.TLS, certs)
 
 	opts := []ldap.DialOpt{
 		ldap.DialWithDialer(&net.Dialer{Timeout: config.Timeout}),
@@ -104,13 +103,20 @@ func NewPooledLDAPClientFactory(config *schema.AuthenticationBackendLDAP, certs
 
 	sleep := config.Pooling.Timeout / time.Duration(config.Pooling.Retries)
 
+	var lifetime time.Duration
+
+	if config.Address != nil {
+		lifetime = config.Address.ConnMaxLifetime()
+	}
+
 	return &PooledLDAPClientFactory{
-		log:    logging.Logger().WithFields(map[string]any{"provider": "pooled ldap factory"}),
-		config: config,
-		tls:    tlsc,
-		opts:   opts,
-		dialer: dialer,
-		sleep:  sleep,
+		log:      logging.Logger().WithFields(map[string]any{"provider": "pooled ldap factory"}),
+		config:   config,
+		tls:      tlsc,
+		opts:     opts,
+		dialer:   dialer,
+		sleep:    sleep,
+		lifetime: lifetime,
 	}
 }
 
@@ -126,7 +132,8 @@ type PooledLDAPClientFactory struct {
 
 	pool chan *PooledLDAPClient
 
-	sleep time.Duration
+	sleep    time.Duration
+	lifetime time.Duration
 
 	mu      sync.Mutex
 	next    int
@@ -187,7 +194,7 @@ func (f *PooledLDAPClientFactory) dial() (pooled *PooledLDAPClient, err error) {
 
 	f.mu.Lock()
 
-	pooled = &PooledLDAPClient{LDAPExtendedClient: client, log: f.log.WithField("client", f.next)}
+	pooled = &PooledLDAPClient{LDAPExtendedClient: client, log: f.log.WithField("client", f.next), created: time.Now()}
 
 	f.next++
 
@@ -246,12 +253,17 @@ func (f *PooledLDAPClientFactory) acquire(ctx context.Context) (client *PooledLD
 		case <-ctx.Done():
 			return nil, NewPoolCtxErr(fmt.Errorf("error acquiring client: %w", ctx.Err()))
 		case client = <-f.pool:
-			if client.healthy() {
+			switch {
+			case client.expired(f.lifetime):
+				client.log.Trace("Client has exceeded the maximum connection lifetime and is being closed")
+
+				_ = client.Close()
+			case client.healthy():
 				return client, nil
+			default:
+				client.log.Trace("Client is closing or invalid")
 			}
 
-			client.log.Trace("Client is closing or invalid")
-
 			if client, err = f.dial(); err == nil {
 				client.log.Trace("New client acquired")
 
@@ -308,7 +320,18 @@ func (f *PooledLDAPClientFactory) setClosing() {
 type PooledLDAPClient struct {
 	LDAPExtendedClient
 
-	log *logrus.Entry
+	log     *logrus.Entry
+	created time.Time
+}
+
+// expired returns true if the client was created longer ago than the lifetime, where a lifetime of 0 indicates the
+// client may be reused indefinitely.
+func (c *PooledLDAPClient) expired(lifetime time.Duration) bool {
+	if c == nil || lifetime <= 0 {
+		return false
+	}
+
+	return time.Since(c.created) >= lifetime
 }
 
 func (c *PooledLDAPClient) healthy() bool {
@@ -455,6 +455,50 @@ func TestShouldReturnCheckServerSearchErrorPooled(t *testing.T) {
 	assert.NoError(t, provider.Close())
 }
 
+func TestShouldRedialExpiredClientPooled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	config := &schema.AuthenticationBackendLDAP{
+		Address:  MustParseAddress("ldap://127.0.0.1:389?conn_max_lifetime=1ms"),
+		User:     "cn=admin,dc=example,dc=com",
+		Password: "password",
+		Pooling:  schema.AuthenticationBackendLDAPPooling{Count: 1},
+	}
+
+	mockDialer := NewMockLDAPClientDialer(ctrl)
+	mockClient := NewMockLDAPClient(ctrl)
+	mockClientSecond := NewMockLDAPClient(ctrl)
+
+	factory := NewPooledLDAPClientFactory(config, nil, mockDialer)
+
+	gomock.InOrder(
+		mockDialer.EXPECT().DialURL("ldap://127.0.0.1:389?conn_max_lifetime=1ms", gomock.Any()).Return(mockClient, nil),
+		mockClient.EXPECT().SetTimeout(gomock.Eq(time.Second*0)),
+		mockClient.EXPECT().Search(gomock.Any()).Return(&ldap.SearchResult{}, nil),
+		mockClient.EXPECT().Bind(gomock.Eq("cn=admin,dc=example,dc=com"), gomock.Eq("password")).Return(nil),
+		mockClient.EXPECT().Close().Return(nil),
+		mockDialer.EXPECT().DialURL("ldap://127.0.0.1:389?conn_max_lifetime=1ms", gomock.Any()).Return(mockClientSecond, nil),
+		mockClientSecond.EXPECT().SetTimeout(gomock.Eq(time.Second*0)),
+		mockClientSecond.EXPECT().Search(gomock.Any()).Return(&ldap.SearchResult{}, nil),
+		mockClientSecond.EXPECT().Bind(gomock.Eq("cn=admin,dc=example,dc=com"), gomock.Eq("password")).Return(nil),
+	)
+
+	require.NoError(t, factory.Initialize())
+
+	time.Sleep(time.Millisecond * 5)
+
+	client, err := factory.GetClient()
+	require.NoError(t, err)
+
+	pooled, ok := client.(*PooledLDAPClient)
+	require.True(t, ok)
+
+	base, ok := pooled.LDAPExtendedClient.(*LDAPClient)
+	require.True(t, ok)
+	assert.Equal(t, mockClientSecond, base.LDAPBaseClient)
+}
+
 func TestShouldPermitRootDSEFailure(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -1,9 +1,17 @@
 package configuration
 
 import (
+	"crypto/tls"
 	"errors"
 	"math"
+	"mime"
+	"regexp"
 	"time"
+
+	"github.com/go-jose/go-jose/v4"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/logging"
 )
 
 // DefaultEnvPrefix is the default environment prefix.
@@ -23,10 +31,14 @@ const (
 	extYAML = ".yaml"
 )
 
+// x509CertificateExpiryWarnDays is the number of days before a certificate expires within which a warning is emitted.
+const x509CertificateExpiryWarnDays = 30
+
 const (
-	filterField     = "filter"
-	filterTemplate  = "template"
-	filterExpandEnv = "expand-env"
+	filterField           = "filter"
+	filterTemplate        = "template"
+	filterExpandEnv       = "expand-env"
+	filterExpandEnvValues = "expand-env-values"
 )
 
 var (
@@ -34,6 +46,9 @@ var (
 	errNoSources   = errors.New("no sources provided")
 
 	errDecodeNonPtrMustHaveValue = errors.New("must have a non-empty value")
+	errDecodeAnchoredTimeFormat  = errors.New("the value must be in the format 'now', 'now+<duration>', or 'now-<duration>'")
+
+	errDecodePasswordDigestNoPrefix = errors.New("the value does not have a recognized '$<algorithm>$' prefix and is not assumed to be a plaintext password as a mistyped digest would silently become the password, plaintext passwords must explicitly have the '$plaintext$' prefix")
 )
 
 const (
@@ -47,6 +62,9 @@ const (
 	errFmtDecodeHookCouldNotParse           = "could not decode '%s' to a %s%s: %w"
 	errFmtDecodeHookCouldNotParseBasic      = "could not decode to a %s%s: %w"
 	errFmtDecodeHookCouldNotParseEmptyValue = "could not decode an empty value to a %s%s: %w"
+	errFmtDecodeHookWarning                 = "decoded '%s' to a %s%s with a potential issue: %w"
+	errFmtDecodeHookWarningBasic            = "decoded to a %s%s with a potential issue: %w"
+	errFmtDecodeHookDuration                = "%w: the duration must be one or more quantity and unit pairs such as '1y2M3d4h' where the unit is one of 'ns', 'us', 'ms', 's', 'm', 'h', 'd' (24h), 'w' (7d), 'M' (30d), or 'y' (365d)"
 
 	errFmtSuffixAutoRemappedKey = "you are not required to make any changes as this has been automatically mapped for you, but to stop this warning being logged you will need to adjust your configuration, and this configuration key and auto-mapping is likely to be removed in %s"
 
@@ -61,6 +79,260 @@ const (
 
 const (
 	durationMax = time.Duration(math.MaxInt64)
+	durationMin = time.Duration(math.MinInt64)
+)
+
+const (
+	anchorNow = "now"
+)
+
+const (
+	rewriteRuleSeparator = "=>"
+)
+
+const (
+	rateLimitSeparator         = "/"
+	rateLimitSubjectSeparator  = ":"
+	rateLimitEndpointSeparator = "="
+)
+
+const (
+	secretFileScheme = "file://"
+)
+
+var (
+	// languageTagsGrandfathered are the lowercase grandfathered tags from RFC5646 section 2.2.8.
+	languageTagsGrandfathered = []string{
+		"en-gb-oed", "i-ami", "i-bnn", "i-default", "i-enochian", "i-hak", "i-klingon", "i-lux", "i-mingo", "i-navajo",
+		"i-pwn", "i-tao", "i-tay", "i-tsu", "sgn-be-fr", "sgn-be-nl", "sgn-ch-de", "art-lojban", "cel-gaulish",
+		"no-bok", "no-nyn", "zh-guoyu", "zh-hakka", "zh-min", "zh-min-nan", "zh-xiang",
+	}
+)
+
+const (
+	pemBlockBeginPrefix = "-----BEGIN"
+)
+
+const (
+	uuidURNPrefix = "urn:uuid:"
+)
+
+const (
+	schemeHTTP  = "http"
+	schemeHTTPS = "https"
+)
+
+const (
+	// urlQueryParamMaxRedirects is the reserved query parameter which specifies the maximum redirect depth of a
+	// schema.RedirectTarget.
+	urlQueryParamMaxRedirects = "__max_redirects"
+)
+
+var (
+	// urlSchemesWithDefaultPort are the url schemes which have a well-known default port.
+	urlSchemesWithDefaultPort = []string{"http", "https", "ws", "wss", "ftp", "ldap", "ldaps", "smtp", "submission", "submissions", "redis", "rediss", "mysql", "postgres", "postgresql"}
+
+	// urlSchemeCounterparts are the url schemes which have a secure or insecure counterpart scheme.
+	urlSchemeCounterparts = map[string]string{
+		"http": "https", "https": "http", "ws": "wss", "wss": "ws", "ldap": "ldaps", "ldaps": "ldap",
+	}
+
+	// urlSchemeDefaultPorts are the well-known default ports of the url schemes which have a counterpart scheme.
+	urlSchemeDefaultPorts = map[string]string{
+		"http": "80", "https": "443", "ws": "80", "wss": "443", "ldap": "389", "ldaps": "636",
+	}
+)
+
+const (
+	ldapAttributeAliasSeparator = "="
+)
+
+const (
+	ipNetworkNegationPrefix = "!"
+
+	// networkDefinitionMaxDepth is the maximum depth network definitions can reference other network definitions.
+	networkDefinitionMaxDepth = 10
+)
+
+var (
+	// regexpOIDCScope checks if a string is a valid scope-token per RFC6749 section 3.3 excluding the ',' and ';'
+	// characters which are used to separate the compact scope to claims mapping.
+	regexpOIDCScope = regexp.MustCompile(`^[\x21\x23-\x2b\x2d-\x3a\x3c-\x5b\x5d-\x7e]+$`)
+)
+
+const (
+	audienceKeySeparator = "@"
+
+	// audienceKeyKIDMaxLength is the maximum length of a key id which is consistent with the jwks key_id option.
+	audienceKeyKIDMaxLength = 100
+)
+
+var (
+	// regexpOIDCKeyID checks if a string is a valid key id which consists of RFC3986 unreserved characters which start
+	// and end with an alphanumeric character, consistent with the jwks key_id option.
+	regexpOIDCKeyID = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9._~-]*[a-zA-Z0-9])?$`)
+
+	// regexpOIDCAudience checks if a string is a valid audience which consists of RFC3986 unreserved characters,
+	// consistent with the client id option.
+	regexpOIDCAudience = regexp.MustCompile(`^[a-zA-Z0-9._~-]+$`)
+)
+
+const (
+	defaultClaimSeparator = "="
+)
+
+var (
+	// regexpOIDCClaimName checks if a string is a valid claim name which starts with a letter or underscore and
+	// otherwise consists of alphanumeric characters and the '_', '.', ':', and '-' characters.
+	regexpOIDCClaimName = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_.:-]*$`)
+)
+
+var (
+	// regexpLDAPAttributeName checks if a string is a valid LDAP attribute description per RFC4512 section 1.4, i.e.
+	// either a descr (keystring) or a numericoid.
+	regexpLDAPAttributeName = regexp.MustCompile(`^([a-zA-Z][a-zA-Z0-9-]*|(0|[1-9][0-9]*)(\.(0|[1-9][0-9]*))+)$`)
+)
+
+const (
+	inlineTLSConfigKeyMinimumVersion         = "min"
+	inlineTLSConfigKeyMaximumVersion         = "max"
+	inlineTLSConfigKeySkipVerify             = "skip_verify"
+	inlineTLSConfigKeyServerName             = "server_name"
+	inlineTLSConfigKeyCertificateAuthorities = "ca"
+)
+
+var (
+	inlineTLSConfigKeys = []string{inlineTLSConfigKeyMinimumVersion, inlineTLSConfigKeyMaximumVersion, inlineTLSConfigKeySkipVerify, inlineTLSConfigKeyServerName, inlineTLSConfigKeyCertificateAuthorities}
+)
+
+const (
+	clientAuthTypeNone    = "none"
+	clientAuthTypeRequest = "request"
+	clientAuthTypeRequire = "require"
+	clientAuthTypeVerify  = "verify"
+)
+
+var (
+	clientAuthTypeNames = []string{clientAuthTypeNone, clientAuthTypeRequest, clientAuthTypeRequire, clientAuthTypeVerify}
+
+	clientAuthTypes = map[string]tls.ClientAuthType{
+		clientAuthTypeNone:    tls.NoClientCert,
+		clientAuthTypeRequest: tls.RequestClientCert,
+		clientAuthTypeRequire: tls.RequireAnyClientCert,
+		clientAuthTypeVerify:  tls.RequireAndVerifyClientCert,
+	}
+)
+
+const (
+	inlineForwardedTrustKeyHops     = "hops"
+	inlineForwardedTrustKeyNetworks = "networks"
+)
+
+var (
+	inlineForwardedTrustKeys = []string{inlineForwardedTrustKeyHops, inlineForwardedTrustKeyNetworks}
+)
+
+const (
+	inlineBackupConfigKeySchedule = "schedule"
+	inlineBackupConfigKeyRetain   = "retain"
+	inlineBackupConfigKeyPath     = "path"
+)
+
+var (
+	inlineBackupConfigKeys = []string{inlineBackupConfigKeySchedule, inlineBackupConfigKeyRetain, inlineBackupConfigKeyPath}
+)
+
+const (
+	inlineDeviceFlowConfigKeyURI      = "uri"
+	inlineDeviceFlowConfigKeyCodeLen  = "code_len"
+	inlineDeviceFlowConfigKeyInterval = "interval"
+
+	// deviceFlowUserCodeLengthMin is the minimum length of a user code which ensures the user code has sufficient
+	// entropy per RFC8628 section 6.1.
+	deviceFlowUserCodeLengthMin = 6
+
+	// deviceFlowUserCodeLengthMax is the maximum length of a user code which ensures users are able to enter it.
+	deviceFlowUserCodeLengthMax = 20
+)
+
+var (
+	inlineDeviceFlowConfigKeys = []string{inlineDeviceFlowConfigKeyURI, inlineDeviceFlowConfigKeyCodeLen, inlineDeviceFlowConfigKeyInterval}
+)
+
+const (
+	inlineWebhookDeliveryKeyTimeout = "timeout"
+	inlineWebhookDeliveryKeyRetries = "retries"
+	inlineWebhookDeliveryKeyBackoff = "backoff"
+)
+
+var (
+	inlineWebhookDeliveryKeys = []string{inlineWebhookDeliveryKeyTimeout, inlineWebhookDeliveryKeyRetries, inlineWebhookDeliveryKeyBackoff}
+)
+
+const (
+	inlineSessionCryptoKeyAlgorithm = "algo"
+	inlineSessionCryptoKeyKey       = "key"
+	inlineSessionCryptoKeyRotate    = "rotate"
+)
+
+var (
+	inlineSessionCryptoKeys = []string{inlineSessionCryptoKeyAlgorithm, inlineSessionCryptoKeyKey, inlineSessionCryptoKeyRotate}
+
+	// sessionCryptoKeyLengths are the permitted key lengths in bytes of each session encryption algorithm.
+	sessionCryptoKeyLengths = map[string][]int{
+		schema.SessionCryptoAlgorithmAESGCM:            {16, 24, 32},
+		schema.SessionCryptoAlgorithmChaCha20Poly1305:  {32},
+		schema.SessionCryptoAlgorithmXChaCha20Poly1305: {32},
+	}
+)
+
+const (
+	cronSecondFieldName = "second"
+)
+
+var (
+	// cronDescriptors are the predefined cron schedule descriptors which are equivalent to a standard cron expression.
+	cronDescriptors = []string{"@yearly", "@annually", "@monthly", "@weekly", "@daily", "@midnight", "@hourly"}
+
+	// cronFieldNames are the names of the fields of a standard cron expression in order.
+	cronFieldNames = []string{"minute", "hour", "day of month", "month", "day of week"}
+
+	// cronFieldBounds are the inclusive minimum and maximum values of the fields of a standard cron expression in order.
+	cronFieldBounds = [][2]int{{0, 59}, {0, 23}, {1, 31}, {1, 12}, {0, 7}}
+
+	// cronSecondFieldBounds are the inclusive minimum and maximum values of the optional leading seconds field.
+	cronSecondFieldBounds = [2]int{0, 59}
+
+	// cronDescriptorExpressions are the standard cron expressions which are equivalent to each of the cronDescriptors.
+	cronDescriptorExpressions = map[string]string{
+		"@yearly":   "0 0 1 1 *",
+		"@annually": "0 0 1 1 *",
+		"@monthly":  "0 0 1 * *",
+		"@weekly":   "0 0 * * 0",
+		"@daily":    "0 0 * * *",
+		"@midnight": "0 0 * * *",
+		"@hourly":   "0 * * * *",
+	}
+)
+
+const (
+	inlineNotificationChannelKeyType    = "type"
+	inlineNotificationChannelKeyAddress = "address"
+	inlineNotificationChannelKeyFrom    = "from"
+	inlineNotificationChannelKeyURL     = "url"
+)
+
+var (
+	inlineNotificationChannelKeys = []string{inlineNotificationChannelKeyType, inlineNotificationChannelKeyAddress, inlineNotificationChannelKeyFrom, inlineNotificationChannelKeyURL}
+)
+
+const (
+	inlineGeoIPConfigKeyProvider = "provider"
+	inlineGeoIPConfigKeyPath     = "path"
+)
+
+var (
+	inlineGeoIPConfigKeys = []string{inlineGeoIPConfigKeyProvider, inlineGeoIPConfigKeyPath}
 )
 
 const (
@@ -90,3 +362,240 @@ var (
 		"webauthn.metadata.validate_status":                   true,
 	}
 )
+
+const (
+	jwkKeyTypeRSA       = "RSA"
+	jwkKeyTypeEC        = "EC"
+	jwkKeyTypeOKP       = "OKP"
+	jwkKeyTypeSymmetric = "oct"
+)
+
+var (
+	errJWKSetNotPermitted = errors.New("the value is a JSON Web Key Set but only a single JSON Web Key may be specified, consider specifying the individual key from the set instead")
+
+	jwkKeyTypes = []string{jwkKeyTypeRSA, jwkKeyTypeEC, jwkKeyTypeOKP, jwkKeyTypeSymmetric}
+
+	// jwkKeyTypeAlgorithms are the algorithms which are supported by each JSON Web Key type.
+	jwkKeyTypeAlgorithms = map[string][]string{
+		jwkKeyTypeRSA: {
+			string(jose.RS256), string(jose.RS384), string(jose.RS512),
+			string(jose.PS256), string(jose.PS384), string(jose.PS512),
+			string(jose.RSA1_5), string(jose.RSA_OAEP), string(jose.RSA_OAEP_256),
+		},
+		jwkKeyTypeEC: {
+			string(jose.ES256), string(jose.ES384), string(jose.ES512),
+			string(jose.ECDH_ES), string(jose.ECDH_ES_A128KW), string(jose.ECDH_ES_A192KW), string(jose.ECDH_ES_A256KW),
+		},
+		jwkKeyTypeOKP: {
+			string(jose.EdDSA),
+		},
+		jwkKeyTypeSymmetric: {
+			string(jose.HS256), string(jose.HS384), string(jose.HS512),
+			string(jose.A128KW), string(jose.A192KW), string(jose.A256KW), string(jose.DIRECT),
+		},
+	}
+)
+
+const (
+	inlineReputationFeedKeyRefresh = "refresh"
+)
+
+var (
+	inlineReputationFeedKeys = []string{inlineReputationFeedKeyRefresh}
+)
+
+const (
+	inlineAuditSinkKeyType   = "type"
+	inlineAuditSinkKeyPath   = "path"
+	inlineAuditSinkKeyFormat = "format"
+	inlineAuditSinkKeyRotate = "rotate"
+)
+
+const (
+	inlineHealthCheckKeyPath      = "path"
+	inlineHealthCheckKeyInterval  = "interval"
+	inlineHealthCheckKeyTimeout   = "timeout"
+	inlineHealthCheckKeyHealthy   = "healthy"
+	inlineHealthCheckKeyUnhealthy = "unhealthy"
+)
+
+var (
+	inlineHealthCheckKeys = []string{inlineHealthCheckKeyPath, inlineHealthCheckKeyInterval, inlineHealthCheckKeyTimeout, inlineHealthCheckKeyHealthy, inlineHealthCheckKeyUnhealthy}
+)
+
+var (
+	inlineAuditSinkKeys = []string{inlineAuditSinkKeyType, inlineAuditSinkKeyPath, inlineAuditSinkKeyFormat, inlineAuditSinkKeyRotate}
+
+	// auditSinkFormats are the formats of the audit log entries which are the same as the log formats.
+	auditSinkFormats = []string{logging.FormatText, logging.FormatJSON}
+)
+
+var (
+	// cssNamedColors are the named colors from the CSS Color Module Level 4 specification and their hex values.
+	cssNamedColors = map[string]uint32{
+		"aliceblue":            0xf0f8ff,
+		"antiquewhite":         0xfaebd7,
+		"aqua":                 0x00ffff,
+		"aquamarine":           0x7fffd4,
+		"azure":                0xf0ffff,
+		"beige":                0xf5f5dc,
+		"bisque":               0xffe4c4,
+		"black":                0x000000,
+		"blanchedalmond":       0xffebcd,
+		"blue":                 0x0000ff,
+		"blueviolet":           0x8a2be2,
+		"brown":                0xa52a2a,
+		"burlywood":            0xdeb887,
+		"cadetblue":            0x5f9ea0,
+		"chartreuse":           0x7fff00,
+		"chocolate":            0xd2691e,
+		"coral":                0xff7f50,
+		"cornflowerblue":       0x6495ed,
+		"cornsilk":             0xfff8dc,
+		"crimson":              0xdc143c,
+		"cyan":                 0x00ffff,
+		"darkblue":             0x00008b,
+		"darkcyan":             0x008b8b,
+		"darkgoldenrod":        0xb8860b,
+		"darkgray":             0xa9a9a9,
+		"darkgreen":            0x006400,
+		"darkgrey":             0xa9a9a9,
+		"darkkhaki":            0xbdb76b,
+		"darkmagenta":          0x8b008b,
+		"darkolivegreen":       0x556b2f,
+		"darkorange":           0xff8c00,
+		"darkorchid":           0x9932cc,
+		"darkred":              0x8b0000,
+		"darksalmon":           0xe9967a,
+		"darkseagreen":         0x8fbc8f,
+		"darkslateblue":        0x483d8b,
+		"darkslategray":        0x2f4f4f,
+		"darkslategrey":        0x2f4f4f,
+		"darkturquoise":        0x00ced1,
+		"darkviolet":           0x9400d3,
+		"deeppink":             0xff1493,
+		"deepskyblue":          0x00bfff,
+		"dimgray":              0x696969,
+		"dimgrey":              0x696969,
+		"dodgerblue":           0x1e90ff,
+		"firebrick":            0xb22222,
+		"floralwhite":          0xfffaf0,
+		"forestgreen":          0x228b22,
+		"fuchsia":              0xff00ff,
+		"gainsboro":            0xdcdcdc,
+		"ghostwhite":           0xf8f8ff,
+		"gold":                 0xffd700,
+		"goldenrod":            0xdaa520,
+		"gray":                 0x808080,
+		"green":                0x008000,
+		"greenyellow":          0xadff2f,
+		"grey":                 0x808080,
+		"honeydew":             0xf0fff0,
+		"hotpink":              0xff69b4,
+		"indianred":            0xcd5c5c,
+		"indigo":               0x4b0082,
+		"ivory":                0xfffff0,
+		"khaki":                0xf0e68c,
+		"lavender":             0xe6e6fa,
+		"lavenderblush":        0xfff0f5,
+		"lawngreen":            0x7cfc00,
+		"lemonchiffon":         0xfffacd,
+		"lightblue":            0xadd8e6,
+		"lightcoral":           0xf08080,
+		"lightcyan":            0xe0ffff,
+		"lightgoldenrodyellow": 0xfafad2,
+		"lightgray":            0xd3d3d3,
+		"lightgreen":           0x90ee90,
+		"lightgrey":            0xd3d3d3,
+		"lightpink":            0xffb6c1,
+		"lightsalmon":          0xffa07a,
+		"lightseagreen":        0x20b2aa,
+		"lightskyblue":         0x87cefa,
+		"lightslategray":       0x778899,
+		"lightslategrey":       0x778899,
+		"lightsteelblue":       0xb0c4de,
+		"lightyellow":          0xffffe0,
+		"lime":                 0x00ff00,
+		"limegreen":            0x32cd32,
+		"linen":                0xfaf0e6,
+		"magenta":              0xff00ff,
+		"maroon":               0x800000,
+		"mediumaquamarine":     0x66cdaa,
+		"mediumblue":           0x0000cd,
+		"mediumorchid":         0xba55d3,
+		"mediumpurple":         0x9370db,
+		"mediumseagreen":       0x3cb371,
+		"mediumslateblue":      0x7b68ee,
+		"mediumspringgreen":    0x00fa9a,
+		"mediumturquoise":      0x48d1cc,
+		"mediumvioletred":      0xc71585,
+		"midnightblue":         0x191970,
+		"mintcream":            0xf5fffa,
+		"mistyrose":            0xffe4e1,
+		"moccasin":             0xffe4b5,
+		"navajowhite":          0xffdead,
+		"navy":                 0x000080,
+		"oldlace":              0xfdf5e6,
+		"olive":                0x808000,
+		"olivedrab":            0x6b8e23,
+		"orange":               0xffa500,
+		"orangered":            0xff4500,
+		"orchid":               0xda70d6,
+		"palegoldenrod":        0xeee8aa,
+		"palegreen":            0x98fb98,
+		"paleturquoise":        0xafeeee,
+		"palevioletred":        0xdb7093,
+		"papayawhip":           0xffefd5,
+		"peachpuff":            0xffdab9,
+		"peru":                 0xcd853f,
+		"pink":                 0xffc0cb,
+		"plum":                 0xdda0dd,
+		"powderblue":           0xb0e0e6,
+		"purple":               0x800080,
+		"rebeccapurple":        0x663399,
+		"red":                  0xff0000,
+		"rosybrown":            0xbc8f8f,
+		"royalblue":            0x4169e1,
+		"saddlebrown":          0x8b4513,
+		"salmon":               0xfa8072,
+		"sandybrown":           0xf4a460,
+		"seagreen":             0x2e8b57,
+		"seashell":             0xfff5ee,
+		"sienna":               0xa0522d,
+		"silver":               0xc0c0c0,
+		"skyblue":              0x87ceeb,
+		"slateblue":            0x6a5acd,
+		"slategray":            0x708090,
+		"slategrey":            0x708090,
+		"snow":                 0xfffafa,
+		"springgreen":          0x00ff7f,
+		"steelblue":            0x4682b4,
+		"tan":                  0xd2b48c,
+		"teal":                 0x008080,
+		"thistle":              0xd8bfd8,
+		"tomato":               0xff6347,
+		"turquoise":            0x40e0d0,
+		"violet":               0xee82ee,
+		"wheat":                0xf5deb3,
+		"white":                0xffffff,
+		"whitesmoke":           0xf5f5f5,
+		"yellow":               0xffff00,
+		"yellowgreen":          0x9acd32,
+	}
+)
+
+var (
+	// mailAddressWordDecoder decodes RFC2047 encoded-words in mail address display names.
+	mailAddressWordDecoder = &mime.WordDecoder{}
+)
+
+const (
+	envRefPrefix       = "${"
+	envRefSuffix       = "}"
+	envRefDefault      = ":-"
+	envRefEscapeDollar = "$$"
+)
+
+var (
+	regexpEnvVarName = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+)
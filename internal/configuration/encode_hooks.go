@@ -0,0 +1,718 @@
+package configuration
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"math"
+	"net"
+	"net/mail"
+	"net/url"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/text/language"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/utils"
+)
+
+// EncodeHookFunc converts a value into the representation which is understood by the decode hooks. The handled result
+// is false if the hook doesn't handle the type of the value, in which case the value is encoded by Encode.
+type EncodeHookFunc func(value reflect.Value) (result any, handled bool, err error)
+
+// ComposeEncodeHookFunc composes multiple encode hooks into a single encode hook. The result of the first hook which
+// handles the value is used.
+func ComposeEncodeHookFunc(hooks ...EncodeHookFunc) EncodeHookFunc {
+	return func(value reflect.Value) (result any, handled bool, err error) {
+		for _, hook := range hooks {
+			if result, handled, err = hook(value); handled || err != nil {
+				return result, handled, err
+			}
+		}
+
+		return nil, false, nil
+	}
+}
+
+// EncodeHooksComposeAll composes all encode hooks. It's the inverse of DecodeHooksComposeAll, and a value encoded with
+// it can be decoded with DecodeHooksComposeAll without any loss.
+func EncodeHooksComposeAll() EncodeHookFunc {
+	return ComposeEncodeHookFunc(
+		TimeDurationToStringEncodeHookFunc(),
+		RefreshIntervalDurationToStringEncodeHookFunc(),
+		AnchoredTimeToStringEncodeHookFunc(),
+		URLToStringEncodeHookFunc(),
+		RegexpToStringEncodeHookFunc(),
+		AddressToStringEncodeHookFunc(),
+		X509ToStringEncodeHookFunc(),
+		TLSVersionToStringEncodeHookFunc(),
+		ClientAuthTypeToStringEncodeHookFunc(),
+		PasswordDigestToStringEncodeHookFunc(),
+		NetworkToStringEncodeHookFunc(),
+		MailAddressToStringEncodeHookFunc(),
+		UUIDToStringEncodeHookFunc(),
+		LanguageTagToStringEncodeHookFunc(),
+		TimeLocationToStringEncodeHookFunc(),
+		CacheDSNToStringEncodeHookFunc(),
+		CertFingerprintToStringEncodeHookFunc(),
+		DefaultClaimsToStringEncodeHookFunc(),
+		WeightedLocaleToStringEncodeHookFunc(),
+		RewriteRuleToStringEncodeHookFunc(),
+		LDAPAttrToStringEncodeHookFunc(),
+		AudienceKeyToStringEncodeHookFunc(),
+		SessionCryptoToStringEncodeHookFunc(),
+		ReputationFeedToStringEncodeHookFunc(),
+		CronScheduleToStringEncodeHookFunc(),
+		AuditSinkToStringEncodeHookFunc(),
+		ColorToStringEncodeHookFunc(),
+		RateLimitToStringEncodeHookFunc(),
+	)
+}
+
+// Encode converts the value into a tree of map[string]any, []any, and scalar values using the encode hook, which is
+// suitable to be marshalled as YAML or another configuration format. Struct fields are keyed by their 'koanf' tag and
+// fields with a zero value are omitted.
+func Encode(value any, hook EncodeHookFunc) (result any, err error) {
+	return encode("", reflect.ValueOf(value), hook)
+}
+
+//nolint:gocyclo
+func encode(path string, value reflect.Value, hook EncodeHookFunc) (result any, err error) {
+	if !value.IsValid() {
+		return nil, nil
+	}
+
+	var handled bool
+
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return nil, nil
+		}
+
+		if result, handled, err = encodeHook(path, value, hook); handled || err != nil {
+			return result, err
+		}
+
+		value = value.Elem()
+	}
+
+	if result, handled, err = encodeHook(path, value, hook); handled || err != nil {
+		return result, err
+	}
+
+	switch value.Kind() {
+	case reflect.Struct:
+		return encodeStruct(path, value, hook)
+	case reflect.Map:
+		if value.IsNil() {
+			return nil, nil
+		}
+
+		m := make(map[string]any, value.Len())
+
+		iter := value.MapRange()
+
+		for iter.Next() {
+			key := fmt.Sprint(iter.Key().Interface())
+
+			var v any
+
+			if v, err = encode(encodePath(path, key), iter.Value(), hook); err != nil {
+				return nil, err
+			}
+
+			m[key] = v
+		}
+
+		return m, nil
+	case reflect.Slice, reflect.Array:
+		if value.Kind() == reflect.Slice && value.IsNil() {
+			return nil, nil
+		}
+
+		s := make([]any, value.Len())
+
+		for i := 0; i < value.Len(); i++ {
+			if s[i], err = encode(fmt.Sprintf("%s[%d]", path, i), value.Index(i), hook); err != nil {
+				return nil, err
+			}
+		}
+
+		return s, nil
+	case reflect.String:
+		return value.String(), nil
+	case reflect.Bool:
+		return value.Bool(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return value.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return value.Uint(), nil
+	case reflect.Float32, reflect.Float64:
+		return value.Float(), nil
+	default:
+		return nil, fmt.Errorf("error occurred encoding the key '%s': the type '%s' can't be encoded", path, value.Type())
+	}
+}
+
+func encodeHook(path string, value reflect.Value, hook EncodeHookFunc) (result any, handled bool, err error) {
+	if hook == nil {
+		return nil, false, nil
+	}
+
+	if result, handled, err = hook(value); err != nil {
+		return nil, true, fmt.Errorf("error occurred encoding the key '%s': %w", path, err)
+	}
+
+	return result, handled, nil
+}
+
+func encodeStruct(path string, value reflect.Value, hook EncodeHookFunc) (result map[string]any, err error) {
+	result = map[string]any{}
+
+	t := value.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		if !field.IsExported() {
+			continue
+		}
+
+		name, options, _ := strings.Cut(field.Tag.Get("koanf"), ",")
+
+		if name == "-" {
+			continue
+		}
+
+		fieldValue := value.Field(i)
+
+		if fieldValue.IsZero() {
+			continue
+		}
+
+		squash := utils.IsStringInSlice("squash", strings.Split(options, ","))
+
+		if name == "" && !squash {
+			name = strings.ToLower(field.Name)
+		}
+
+		var encoded any
+
+		if encoded, err = encode(encodePath(path, name), fieldValue, hook); err != nil {
+			return nil, err
+		}
+
+		switch m, ok := encoded.(map[string]any); {
+		case squash && ok:
+			for k, v := range m {
+				result[k] = v
+			}
+		case encoded != nil:
+			result[name] = encoded
+		}
+	}
+
+	return result, nil
+}
+
+func encodePath(path, key string) string {
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
+
+// encodeHookFunc returns an EncodeHookFunc which handles values of the type T or *T with the provided func.
+func encodeHookFunc[T any](fn func(value *T) (result any, err error)) EncodeHookFunc {
+	expectedType := reflect.TypeOf((*T)(nil)).Elem()
+	expectedTypePtr := reflect.PointerTo(expectedType)
+
+	return func(value reflect.Value) (result any, handled bool, err error) {
+		switch value.Type() {
+		case expectedTypePtr:
+			result, err = fn(value.Interface().(*T))
+		case expectedType:
+			v := value.Interface().(T)
+
+			result, err = fn(&v)
+		default:
+			return nil, false, nil
+		}
+
+		return result, true, err
+	}
+}
+
+// TimeDurationToStringEncodeHookFunc encodes a time.Duration as the canonical duration string produced by
+// utils.FormatDurationString.
+func TimeDurationToStringEncodeHookFunc() EncodeHookFunc {
+	return encodeHookFunc(func(value *time.Duration) (any, error) {
+		return encodeDuration(*value)
+	})
+}
+
+// RefreshIntervalDurationToStringEncodeHookFunc encodes a schema.RefreshIntervalDuration as either 'always', 'disable',
+// or the canonical duration string.
+func RefreshIntervalDurationToStringEncodeHookFunc() EncodeHookFunc {
+	return encodeHookFunc(func(value *schema.RefreshIntervalDuration) (any, error) {
+		switch {
+		case value.Always():
+			return schema.ProfileRefreshAlways, nil
+		case value.Never():
+			return schema.ProfileRefreshDisabled, nil
+		default:
+			return encodeDuration(value.Value())
+		}
+	})
+}
+
+// AnchoredTimeToStringEncodeHookFunc encodes a schema.AnchoredTime as its offset from the anchor in the form of 'now',
+// 'now+<duration>', or 'now-<duration>'.
+func AnchoredTimeToStringEncodeHookFunc() EncodeHookFunc {
+	return encodeHookFunc(func(value *schema.AnchoredTime) (any, error) {
+		switch offset := value.Offset(); {
+		case offset > 0:
+			return anchorNow + "+" + utils.FormatDurationString(offset), nil
+		case offset < 0:
+			return anchorNow + utils.FormatDurationString(offset), nil
+		default:
+			return anchorNow, nil
+		}
+	})
+}
+
+// URLToStringEncodeHookFunc encodes a url.URL, schema.URLWithIDN, or schema.RedirectTarget as its string form. The
+// maximum depth of a schema.RedirectTarget is encoded as the reserved '__max_redirects' query parameter.
+func URLToStringEncodeHookFunc() EncodeHookFunc {
+	return ComposeEncodeHookFunc(
+		encodeHookFunc(func(value *url.URL) (any, error) {
+			return value.String(), nil
+		}),
+		encodeHookFunc(func(value *schema.URLWithIDN) (any, error) {
+			return value.String(), nil
+		}),
+		encodeHookFunc(func(value *schema.URLWithUserInfo) (any, error) {
+			if value.URL == nil {
+				return nil, nil
+			}
+
+			return value.Unredacted().String(), nil
+		}),
+		encodeHookFunc(func(value *schema.RedirectTarget) (any, error) {
+			if value.URL == nil {
+				return nil, nil
+			}
+
+			if value.MaxDepth < 0 {
+				return value.URL.String(), nil
+			}
+
+			u := *value.URL
+
+			param := urlQueryParamMaxRedirects + "=" + strconv.Itoa(value.MaxDepth)
+
+			if u.RawQuery == "" {
+				u.RawQuery = param
+			} else {
+				u.RawQuery += "&" + param
+			}
+
+			return u.String(), nil
+		}),
+	)
+}
+
+// RegexpToStringEncodeHookFunc encodes a regexp.Regexp as its pattern.
+func RegexpToStringEncodeHookFunc() EncodeHookFunc {
+	return encodeHookFunc(func(value *regexp.Regexp) (any, error) {
+		return value.String(), nil
+	})
+}
+
+// AddressToStringEncodeHookFunc encodes a schema.Address or any of the specific address types as its unredacted string
+// form.
+func AddressToStringEncodeHookFunc() EncodeHookFunc {
+	return ComposeEncodeHookFunc(
+		encodeHookFunc(func(value *schema.Address) (any, error) {
+			return value.String(), nil
+		}),
+		encodeHookFunc(func(value *schema.AddressTCP) (any, error) {
+			return value.String(), nil
+		}),
+		encodeHookFunc(func(value *schema.AddressUDP) (any, error) {
+			return value.String(), nil
+		}),
+		encodeHookFunc(func(value *schema.AddressLDAP) (any, error) {
+			return value.String(), nil
+		}),
+		encodeHookFunc(func(value *schema.AddressSMTP) (any, error) {
+			return value.String(), nil
+		}),
+		encodeHookFunc(func(value *schema.AddressQUIC) (any, error) {
+			return value.String(), nil
+		}),
+	)
+}
+
+// X509ToStringEncodeHookFunc encodes a schema.X509CertificateChain, *x509.Certificate, and the supported public and
+// private keys as PEM. Private keys are encoded in the PKCS #8 format.
+func X509ToStringEncodeHookFunc() EncodeHookFunc {
+	expectedTypes := []reflect.Type{
+		reflect.TypeOf(&x509.Certificate{}),
+		reflect.TypeOf(&rsa.PrivateKey{}),
+		reflect.TypeOf(&ecdsa.PrivateKey{}),
+		reflect.TypeOf(ed25519.PrivateKey{}),
+		reflect.TypeOf(&rsa.PublicKey{}),
+		reflect.TypeOf(&ecdsa.PublicKey{}),
+		reflect.TypeOf(ed25519.PublicKey{}),
+	}
+
+	return ComposeEncodeHookFunc(
+		encodeHookFunc(func(value *schema.X509CertificateChain) (any, error) {
+			encoded, err := value.EncodePEM()
+
+			return string(encoded), err
+		}),
+		func(value reflect.Value) (result any, handled bool, err error) {
+			if !isTypeInSlice(value.Type(), expectedTypes) {
+				return nil, false, nil
+			}
+
+			var block *pem.Block
+
+			if block, err = utils.PEMBlockFromX509Key(value.Interface(), false); err != nil {
+				return nil, true, err
+			}
+
+			return string(pem.EncodeToMemory(block)), true, nil
+		},
+	)
+}
+
+// TLSVersionToStringEncodeHookFunc encodes a schema.TLSVersion as its name.
+func TLSVersionToStringEncodeHookFunc() EncodeHookFunc {
+	return encodeHookFunc(func(value *schema.TLSVersion) (any, error) {
+		return value.String(), nil
+	})
+}
+
+// ClientAuthTypeToStringEncodeHookFunc encodes a tls.ClientAuthType as the name of the client authentication policy.
+func ClientAuthTypeToStringEncodeHookFunc() EncodeHookFunc {
+	return encodeHookFunc(func(value *tls.ClientAuthType) (any, error) {
+		for name, clientAuthType := range clientAuthTypes {
+			if clientAuthType == *value {
+				return name, nil
+			}
+		}
+
+		return nil, fmt.Errorf("the client authentication type '%d' has no name", *value)
+	})
+}
+
+// PasswordDigestToStringEncodeHookFunc encodes a schema.PasswordDigest as its encoded digest.
+func PasswordDigestToStringEncodeHookFunc() EncodeHookFunc {
+	return encodeHookFunc(func(value *schema.PasswordDigest) (any, error) {
+		if !value.Valid() {
+			return nil, nil
+		}
+
+		return value.String(), nil
+	})
+}
+
+// NetworkToStringEncodeHookFunc encodes a net.IP, net.IPNet, net.HardwareAddr, or schema.IPNetworkRule as its string
+// form.
+func NetworkToStringEncodeHookFunc() EncodeHookFunc {
+	return ComposeEncodeHookFunc(
+		encodeHookFunc(func(value *net.IP) (any, error) {
+			return value.String(), nil
+		}),
+		encodeHookFunc(func(value *net.HardwareAddr) (any, error) {
+			return value.String(), nil
+		}),
+		encodeHookFunc(func(value *net.IPNet) (any, error) {
+			return value.String(), nil
+		}),
+		encodeHookFunc(func(value *schema.IPNetworkRule) (any, error) {
+			return value.String(), nil
+		}),
+	)
+}
+
+// MailAddressToStringEncodeHookFunc encodes a mail.Address as its RFC 5322 form. Display names which are not ASCII are
+// encoded as RFC 2047 encoded-words which StringToMailAddressHookFunc decodes back to their UTF-8 form.
+func MailAddressToStringEncodeHookFunc() EncodeHookFunc {
+	return encodeHookFunc(func(value *mail.Address) (any, error) {
+		return value.String(), nil
+	})
+}
+
+// UUIDToStringEncodeHookFunc encodes a uuid.UUID as its string form.
+func UUIDToStringEncodeHookFunc() EncodeHookFunc {
+	return encodeHookFunc(func(value *uuid.UUID) (any, error) {
+		return value.String(), nil
+	})
+}
+
+// LanguageTagToStringEncodeHookFunc encodes a language.Tag as its BCP 47 form.
+func LanguageTagToStringEncodeHookFunc() EncodeHookFunc {
+	return encodeHookFunc(func(value *language.Tag) (any, error) {
+		return value.String(), nil
+	})
+}
+
+// TimeLocationToStringEncodeHookFunc encodes a time.Location as its name.
+func TimeLocationToStringEncodeHookFunc() EncodeHookFunc {
+	return encodeHookFunc(func(value *time.Location) (any, error) {
+		return value.String(), nil
+	})
+}
+
+// CacheDSNToStringEncodeHookFunc encodes a schema.CacheDSN as its unredacted DSN.
+func CacheDSNToStringEncodeHookFunc() EncodeHookFunc {
+	return encodeHookFunc(func(value *schema.CacheDSN) (any, error) {
+		return value.URL().String(), nil
+	})
+}
+
+// CertFingerprintToStringEncodeHookFunc encodes a schema.Fingerprint as hex with the bytes separated by colons.
+func CertFingerprintToStringEncodeHookFunc() EncodeHookFunc {
+	return encodeHookFunc(func(value *schema.Fingerprint) (any, error) {
+		parts := make([]string, len(*value))
+
+		for i, b := range *value {
+			parts[i] = hex.EncodeToString([]byte{b})
+		}
+
+		return strings.Join(parts, ":"), nil
+	})
+}
+
+// DefaultClaimsToStringEncodeHookFunc encodes schema.DefaultClaims in the 'claim=value' form. String values are always
+// quoted so they're not decoded as another type.
+func DefaultClaimsToStringEncodeHookFunc() EncodeHookFunc {
+	return encodeHookFunc(func(value *schema.DefaultClaims) (any, error) {
+		names := make([]string, 0, len(*value))
+
+		for name := range *value {
+			names = append(names, name)
+		}
+
+		sort.Strings(names)
+
+		entries := make([]string, len(names))
+
+		for i, name := range names {
+			var raw string
+
+			switch v := (*value)[name].(type) {
+			case string:
+				raw = strconv.Quote(v)
+			case bool:
+				raw = strconv.FormatBool(v)
+			case int64:
+				raw = strconv.FormatInt(v, 10)
+			case float64:
+				if math.IsInf(v, 0) || math.IsNaN(v) {
+					return nil, fmt.Errorf("the claim '%s' has the value '%v' which is not finite", name, v)
+				}
+
+				if raw = strconv.FormatFloat(v, 'g', -1, 64); !strings.ContainsAny(raw, ".eE") {
+					raw += ".0"
+				}
+			default:
+				return nil, fmt.Errorf("the claim '%s' has a value of the unsupported type '%T'", name, v)
+			}
+
+			entries[i] = name + defaultClaimSeparator + raw
+		}
+
+		return strings.Join(entries, ","), nil
+	})
+}
+
+// WeightedLocaleToStringEncodeHookFunc encodes a schema.WeightedLocale in the '<tag>;q=<weight>' form. The weight is
+// omitted if it's the default weight of 1.
+func WeightedLocaleToStringEncodeHookFunc() EncodeHookFunc {
+	return encodeHookFunc(func(value *schema.WeightedLocale) (any, error) {
+		if value.Q == 1 {
+			return value.Tag.String(), nil
+		}
+
+		return value.Tag.String() + ";q=" + strconv.FormatFloat(value.Q, 'f', -1, 64), nil
+	})
+}
+
+// RewriteRuleToStringEncodeHookFunc encodes a schema.RewriteRule in the '<pattern>=><replacement>' form.
+func RewriteRuleToStringEncodeHookFunc() EncodeHookFunc {
+	return encodeHookFunc(func(value *schema.RewriteRule) (any, error) {
+		if value.Pattern == nil {
+			return nil, fmt.Errorf("the rule has an empty pattern")
+		}
+
+		return value.Pattern.String() + rewriteRuleSeparator + value.Replacement, nil
+	})
+}
+
+// LDAPAttrToStringEncodeHookFunc encodes a schema.LDAPAttr in the '<alias>=<name>' form, or as the name if it has no
+// alias.
+func LDAPAttrToStringEncodeHookFunc() EncodeHookFunc {
+	return encodeHookFunc(func(value *schema.LDAPAttr) (any, error) {
+		if value.Alias == "" {
+			return value.Name, nil
+		}
+
+		return value.Alias + ldapAttributeAliasSeparator + value.Name, nil
+	})
+}
+
+// AudienceKeyToStringEncodeHookFunc encodes a schema.AudienceKey in the '<kid>@<audience>' form.
+func AudienceKeyToStringEncodeHookFunc() EncodeHookFunc {
+	return encodeHookFunc(func(value *schema.AudienceKey) (any, error) {
+		return value.KID + audienceKeySeparator + value.Audience, nil
+	})
+}
+
+// SessionCryptoToStringEncodeHookFunc encodes a schema.SessionCrypto in the
+// 'algo=<algorithm>;key=<base64>;rotate=<duration>' form. The rotate option is omitted when it's zero.
+func SessionCryptoToStringEncodeHookFunc() EncodeHookFunc {
+	return encodeHookFunc(func(value *schema.SessionCrypto) (any, error) {
+		options := []string{
+			inlineSessionCryptoKeyAlgorithm + "=" + value.Algorithm,
+			inlineSessionCryptoKeyKey + "=" + base64.StdEncoding.EncodeToString(value.Key),
+		}
+
+		if value.Rotate != 0 {
+			rotate, err := encodeDuration(value.Rotate)
+			if err != nil {
+				return nil, err
+			}
+
+			options = append(options, inlineSessionCryptoKeyRotate+"="+rotate)
+		}
+
+		return strings.Join(options, ";"), nil
+	})
+}
+
+// ReputationFeedToStringEncodeHookFunc encodes a schema.ReputationFeed in the '<url>;refresh=<duration>' form.
+func ReputationFeedToStringEncodeHookFunc() EncodeHookFunc {
+	return encodeHookFunc(func(value *schema.ReputationFeed) (any, error) {
+		if value.URL == nil {
+			return nil, fmt.Errorf("the reputation feed has no url")
+		}
+
+		refresh, err := encodeDuration(value.Refresh)
+		if err != nil {
+			return nil, err
+		}
+
+		return value.URL.String() + ";" + inlineReputationFeedKeyRefresh + "=" + refresh, nil
+	})
+}
+
+// CronScheduleToStringEncodeHookFunc encodes a schema.CronSchedule as the expression it was decoded from.
+func CronScheduleToStringEncodeHookFunc() EncodeHookFunc {
+	return encodeHookFunc(func(value *schema.CronSchedule) (any, error) {
+		return value.Expression, nil
+	})
+}
+
+// AuditSinkToStringEncodeHookFunc encodes a schema.AuditSink in the 'type=<type>;<key>=<value>' form. The options
+// which have a zero value are omitted.
+func AuditSinkToStringEncodeHookFunc() EncodeHookFunc {
+	return encodeHookFunc(func(value *schema.AuditSink) (any, error) {
+		options := []string{inlineAuditSinkKeyType + "=" + value.Type}
+
+		if value.Path != "" {
+			options = append(options, inlineAuditSinkKeyPath+"="+value.Path)
+		}
+
+		if value.Format != "" {
+			options = append(options, inlineAuditSinkKeyFormat+"="+value.Format)
+		}
+
+		if value.Rotate != 0 {
+			options = append(options, inlineAuditSinkKeyRotate+"="+strconv.FormatInt(int64(value.Rotate), 10))
+		}
+
+		return strings.Join(options, ";"), nil
+	})
+}
+
+// ColorToStringEncodeHookFunc encodes a schema.Color as its canonical hex form in the format of '#rrggbb'.
+func ColorToStringEncodeHookFunc() EncodeHookFunc {
+	return encodeHookFunc(func(value *schema.Color) (any, error) {
+		return value.String(), nil
+	})
+}
+
+// RateLimitToStringEncodeHookFunc encodes a schema.RateLimitTiers in the 'subject:requests/period' form and a
+// schema.EndpointRateLimits in the 'path=requests/period' form.
+func RateLimitToStringEncodeHookFunc() EncodeHookFunc {
+	return ComposeEncodeHookFunc(
+		encodeHookFunc(func(value *schema.RateLimitTiers) (any, error) {
+			return encodeRateLimits(*value, rateLimitSubjectSeparator)
+		}),
+		encodeHookFunc(func(value *schema.EndpointRateLimits) (any, error) {
+			return encodeRateLimits(*value, rateLimitEndpointSeparator)
+		}),
+	)
+}
+
+func encodeRateLimits(limits map[string]schema.RateLimit, separator string) (result any, err error) {
+	keys := make([]string, 0, len(limits))
+
+	for key := range limits {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	entries := make([]string, len(keys))
+
+	for i, key := range keys {
+		var period string
+
+		if period, err = encodeDuration(limits[key].Period); err != nil {
+			return nil, err
+		}
+
+		entries[i] = fmt.Sprintf("%s%s%d%s%s", key, separator, limits[key].Requests, rateLimitSeparator, period)
+	}
+
+	return strings.Join(entries, ","), nil
+}
+
+func isTypeInSlice(t reflect.Type, types []reflect.Type) bool {
+	for _, typ := range types {
+		if t == typ {
+			return true
+		}
+	}
+
+	return false
+}
+
+// encodeDuration encodes a time.Duration with utils.FormatDurationString. Negative durations are not encoded as
+// utils.ParseDurationString does not accept them, so they would not decode to the same value.
+func encodeDuration(value time.Duration) (encoded string, err error) {
+	if value < 0 {
+		return "", fmt.Errorf("the duration '%s' is negative but only durations which are not negative can be encoded", utils.FormatDurationString(value))
+	}
+
+	return utils.FormatDurationString(value), nil
+}
@@ -1,9 +1,11 @@
 package configuration
 
 import (
+	"fmt"
 	"os"
 	"strings"
 
+	"github.com/authelia/authelia/v4/internal/templates"
 	"github.com/authelia/authelia/v4/internal/utils"
 )
 
@@ -117,3 +119,75 @@ func loadSecret(path string) (value string, err error) {
 
 	return strings.TrimRight(string(content), "\n"), err
 }
+
+// expandEnv replaces the environment variable references in the form of '${VAR}' or '${VAR:-default}' within the value
+// with the result of the lookup. The default is used when the variable is not set or is empty, and a variable which is
+// not set and has no default is an error. A reference to a variable which may contain a secret is always an error. The
+// '$$' sequence produces a literal '$', and every other '$' is left as is. The value is expanded in a single pass, so
+// the result of a lookup or a default is never itself expanded.
+func expandEnv(value string, lookup func(key string) (value string, found bool)) (expanded string, err error) {
+	if !strings.Contains(value, "$") {
+		return value, nil
+	}
+
+	var (
+		b                   strings.Builder
+		ref, name, fallback string
+		found, hasFallback  bool
+	)
+
+	for value != "" {
+		i := strings.IndexByte(value, '$')
+
+		if i == -1 {
+			b.WriteString(value)
+
+			break
+		}
+
+		b.WriteString(value[:i])
+
+		value = value[i:]
+
+		switch {
+		case strings.HasPrefix(value, envRefEscapeDollar):
+			b.WriteByte('$')
+
+			value = value[len(envRefEscapeDollar):]
+		case strings.HasPrefix(value, envRefPrefix):
+			if ref, value, found = strings.Cut(value[len(envRefPrefix):], envRefSuffix); !found {
+				return "", fmt.Errorf("the environment variable reference '%s' is missing the closing '%s'", envRefPrefix+ref, envRefSuffix)
+			}
+
+			name, fallback, hasFallback = strings.Cut(ref, envRefDefault)
+
+			if !regexpEnvVarName.MatchString(name) {
+				return "", fmt.Errorf("the environment variable reference '%s%s%s' does not have a valid variable name", envRefPrefix, ref, envRefSuffix)
+			}
+
+			if templates.IsSecretEnvKey(name) {
+				return "", fmt.Errorf("the environment variable '%s' may contain a secret and can't be referenced", name)
+			}
+
+			var (
+				v   string
+				set bool
+			)
+
+			switch v, set = lookup(name); {
+			case set && v != "":
+				b.WriteString(v)
+			case hasFallback:
+				b.WriteString(fallback)
+			case !set:
+				return "", fmt.Errorf("the environment variable '%s' is not set and the reference does not have a default", name)
+			}
+		default:
+			b.WriteByte('$')
+
+			value = value[1:]
+		}
+	}
+
+	return b.String(), nil
+}
@@ -101,3 +101,61 @@ func TestGetSecretConfigMap(t *testing.T) {
 	assert.True(t, ok)
 	assert.Equal(t, "jwt_secret", key)
 }
+
+func TestExpandEnv(t *testing.T) {
+	env := map[string]string{
+		"HOST":  "auth.example.com",
+		"EMPTY": "",
+		"REF":   "${HOST}",
+		"PRICE": "$$5",
+
+		"AUTHELIA_SESSION_SECRET": "secret",
+	}
+
+	lookup := func(key string) (value string, found bool) {
+		value, found = env[key]
+
+		return value, found
+	}
+
+	testCases := []struct {
+		name     string
+		have     string
+		expected string
+		err      string
+	}{
+		{"ShouldNotExpandWithoutReferences", "https://example.com", "https://example.com", ""},
+		{"ShouldExpandVariable", "https://${HOST}/path", "https://auth.example.com/path", ""},
+		{"ShouldExpandMultipleVariables", "${HOST}:${HOST}", "auth.example.com:auth.example.com", ""},
+		{"ShouldExpandDefaultWhenUnset", "${PORT:-9091}", "9091", ""},
+		{"ShouldExpandDefaultWhenEmpty", "${EMPTY:-default}", "default", ""},
+		{"ShouldExpandEmptyDefault", "a${PORT:-}b", "ab", ""},
+		{"ShouldNotUseDefaultWhenSet", "${HOST:-example.org}", "auth.example.com", ""},
+		{"ShouldExpandEmptyWithoutDefault", "a${EMPTY}b", "ab", ""},
+		{"ShouldExpandEscapedDollar", "$$HOST $${HOST}", "$HOST ${HOST}", ""},
+		{"ShouldNotExpandBareDollar", "^(abc|def)$ $plaintext$example", "^(abc|def)$ $plaintext$example", ""},
+		{"ShouldNotRecurseIntoExpandedValue", "${REF}", "${HOST}", ""},
+		{"ShouldNotRecurseIntoExpandedEscape", "${PRICE}", "$$5", ""},
+		{"ShouldNotRecurseIntoDefault", "${PORT:-${HOST}}", "${HOST}", ""},
+		{"ShouldErrorUnsetWithoutDefault", "https://${MISSING}/path", "", "the environment variable 'MISSING' is not set and the reference does not have a default"},
+		{"ShouldErrorMissingClosingBrace", "https://${HOST/path", "", "the environment variable reference '${HOST/path' is missing the closing '}'"},
+		{"ShouldErrorSecret", "${AUTHELIA_SESSION_SECRET}", "", "the environment variable 'AUTHELIA_SESSION_SECRET' may contain a secret and can't be referenced"},
+		{"ShouldErrorSecretWithDefault", "${AUTHELIA_SESSION_SECRET:-abc}", "", "the environment variable 'AUTHELIA_SESSION_SECRET' may contain a secret and can't be referenced"},
+		{"ShouldErrorInvalidName", "${1HOST}", "", "the environment variable reference '${1HOST}' does not have a valid variable name"},
+		{"ShouldErrorEmptyName", "${:-abc}", "", "the environment variable reference '${:-abc}' does not have a valid variable name"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := expandEnv(tc.have, lookup)
+
+			if tc.err == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, actual)
+			} else {
+				assert.EqualError(t, err, tc.err)
+				assert.Equal(t, "", actual)
+			}
+		})
+	}
+}
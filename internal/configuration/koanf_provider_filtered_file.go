@@ -10,6 +10,7 @@ import (
 	"strings"
 	"text/template"
 
+	"github.com/knadh/koanf/parsers/yaml"
 	"github.com/sirupsen/logrus"
 
 	"github.com/authelia/authelia/v4/internal/logging"
@@ -85,6 +86,40 @@ func (f *ExpandEnvBytesFilter) Filter(in []byte) (out []byte, err error) {
 	return out, nil
 }
 
+// ExpandEnvValuesBytesFilter is a BytesFilter which parses the bytes as YAML and expands the environment variable
+// references within every string value using expandEnv, unlike the ExpandEnvBytesFilter which expands the raw bytes.
+type ExpandEnvValuesBytesFilter struct {
+	log *logrus.Entry
+}
+
+func (f *ExpandEnvValuesBytesFilter) Name() (name string) {
+	return filterExpandEnvValues
+}
+
+func (f *ExpandEnvValuesBytesFilter) Filter(in []byte) (out []byte, err error) {
+	var data map[string]any
+
+	if data, err = yaml.Parser().Unmarshal(in); err != nil {
+		return nil, err
+	}
+
+	if data, err = koanfExpandEnv(data); err != nil {
+		return nil, err
+	}
+
+	if out, err = yaml.Parser().Marshal(data); err != nil {
+		return nil, err
+	}
+
+	if f.log.Level >= logrus.TraceLevel {
+		f.log.
+			WithField("content", base64.RawStdEncoding.EncodeToString(out)).
+			Trace("Expanded Env Values File Filter completed successfully")
+	}
+
+	return out, nil
+}
+
 type TemplateBytesFilter struct {
 	t   *template.Template
 	log *logrus.Entry
@@ -142,6 +177,8 @@ func NewFileFilters(names []string) (filters []BytesFilter, err error) {
 			filters[i] = NewTemplateFileFilter()
 		case filterExpandEnv:
 			filters[i] = NewExpandEnvFileFilter()
+		case filterExpandEnvValues:
+			filters[i] = NewExpandEnvValuesFileFilter()
 		default:
 			return nil, fmt.Errorf("invalid filter named '%s'", name)
 		}
@@ -163,6 +200,14 @@ func NewExpandEnvFileFilter() BytesFilter {
 	}
 }
 
+// NewExpandEnvValuesFileFilter returns a new BytesFilter which expands the environment variable references within the
+// YAML string values.
+func NewExpandEnvValuesFileFilter() BytesFilter {
+	return &ExpandEnvValuesBytesFilter{
+		log: logging.Logger().WithFields(map[string]any{filterField: filterExpandEnvValues}),
+	}
+}
+
 // NewTemplateFileFilter returns a new BytesFilter which passes the bytes through text/template.
 func NewTemplateFileFilter() BytesFilter {
 	return &TemplateBytesFilter{
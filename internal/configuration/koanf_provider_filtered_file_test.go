@@ -3,7 +3,9 @@ package configuration
 import (
 	"testing"
 
+	"github.com/knadh/koanf/parsers/yaml"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewFileFilters(t *testing.T) {
@@ -52,6 +54,11 @@ func TestNewFileFilters(t *testing.T) {
 			[]string{"EXPAND-env"},
 			"",
 		},
+		{
+			"ShouldNotErrorOnExpandEnvValuesFilter",
+			[]string{"expand-env-values", "template"},
+			"",
+		},
 		{
 			"ShouldNotErrorOnTemplateFilter",
 			[]string{"template"},
@@ -78,3 +85,34 @@ func TestNewFileFilters(t *testing.T) {
 		})
 	}
 }
+
+func TestExpandEnvValuesBytesFilterShouldNotSplitDottedKeys(t *testing.T) {
+	t.Setenv("ROOT_DOMAIN", "example.org")
+
+	out, err := NewExpandEnvValuesFileFilter().Filter([]byte(`
+identity_providers:
+  oidc:
+    scopes:
+      api.read:
+        claims:
+          - 'https://${ROOT_DOMAIN}/claims/read'
+`))
+
+	require.NoError(t, err)
+
+	data, err := yaml.Parser().Unmarshal(out)
+
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]any{
+		"identity_providers": map[string]any{
+			"oidc": map[string]any{
+				"scopes": map[string]any{
+					"api.read": map[string]any{
+						"claims": []any{"https://example.org/claims/read"},
+					},
+				},
+			},
+		},
+	}, data)
+}
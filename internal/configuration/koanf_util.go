@@ -2,6 +2,7 @@ package configuration
 
 import (
 	"fmt"
+	"os"
 	"sort"
 	"strings"
 
@@ -41,6 +42,56 @@ func koanfGetKeys(ko *koanf.Koanf) (keys []string) {
 	return keys
 }
 
+// koanfExpandEnv expands the environment variable references within every string value of the parsed configuration,
+// including the string values of lists and maps, using expandEnv. The map is walked directly instead of being loaded
+// into a koanf.Koanf so that keys which contain the delimiter are not split into multiple keys.
+func koanfExpandEnv(data map[string]any) (expanded map[string]any, err error) {
+	return koanfExpandEnvMap("", data)
+}
+
+func koanfExpandEnvMap(path string, data map[string]any) (expanded map[string]any, err error) {
+	expanded = make(map[string]any, len(data))
+
+	for key, value := range data {
+		full := key
+
+		if path != "" {
+			full = path + constDelimiter + key
+		}
+
+		if expanded[key], err = koanfExpandEnvValue(full, value); err != nil {
+			return nil, err
+		}
+	}
+
+	return expanded, nil
+}
+
+func koanfExpandEnvValue(path string, value any) (expanded any, err error) {
+	switch v := value.(type) {
+	case string:
+		if expanded, err = expandEnv(v, os.LookupEnv); err != nil {
+			return nil, fmt.Errorf("error occurred expanding the environment variables in the value of the key '%s': %w", path, err)
+		}
+
+		return expanded, nil
+	case []any:
+		items := make([]any, len(v))
+
+		for i, item := range v {
+			if items[i], err = koanfExpandEnvValue(path, item); err != nil {
+				return nil, err
+			}
+		}
+
+		return items, nil
+	case map[string]any:
+		return koanfExpandEnvMap(path, v)
+	default:
+		return value, nil
+	}
+}
+
 func koanfRemapKeys(val *schema.StructValidator, ko *koanf.Koanf, ds map[string]Deprecation, dms []MultiKeyMappedDeprecation) (final *koanf.Koanf, err error) {
 	km := ko.KeyMap()
 	keys := ko.All()
@@ -237,3 +288,18 @@ func koanfRemapKeysMultiMapped(keys map[string]any, val *schema.StructValidator,
 		dm.MapFunc(dm, keys, val)
 	}
 }
+
+func koanfGetNetworkDefinitions(ko *koanf.Koanf) (raw map[string][]string) {
+	networks, ok := ko.Get("definitions.network").(map[string]any)
+	if !ok {
+		return nil
+	}
+
+	raw = make(map[string][]string, len(networks))
+
+	for name, value := range networks {
+		raw[name] = toStringValues(value, ",")
+	}
+
+	return raw
+}
@@ -1,6 +1,7 @@
 package configuration
 
 import (
+	"errors"
 	"fmt"
 	"net"
 
@@ -57,11 +58,19 @@ func LoadDefinitions(val *schema.StructValidator, sources ...Source) (definition
 		return nil, err
 	}
 
+	var networks map[string][]*net.IPNet
+
+	if networks, err = resolveNetworkDefinitions(koanfGetNetworkDefinitions(final)); err != nil {
+		val.Push(fmt.Errorf("error occurred during unmarshaling definitions configuration: %w", err))
+
+		return &schema.Definitions{}, nil
+	}
+
 	legacy := &legacyDefinitions{}
 
 	c := koanf.UnmarshalConf{
 		DecoderConfig: &mapstructure.DecoderConfig{
-			DecodeHook:       DecodeHooksComposeDefinitions(),
+			DecodeHook:       DecodeHooksComposeDefinitions(networks),
 			Metadata:         nil,
 			Result:           legacy,
 			WeaklyTypedInput: true,
@@ -69,7 +78,9 @@ func LoadDefinitions(val *schema.StructValidator, sources ...Source) (definition
 	}
 
 	if err = final.UnmarshalWithConf("", legacy, c); err != nil {
-		val.Push(fmt.Errorf("error occurred during unmarshaling definitions configuration: %w", err))
+		for _, err = range flattenDecodeErrors(err) {
+			val.Push(fmt.Errorf("error occurred during unmarshaling definitions configuration: %w", err))
+		}
 	}
 
 	d := legacy.Definitions
@@ -135,7 +146,7 @@ func unmarshal(ko *koanf.Koanf, val *schema.StructValidator, path string, o any,
 
 	c := koanf.UnmarshalConf{
 		DecoderConfig: &mapstructure.DecoderConfig{
-			DecodeHook:       DecodeHooksComposeAll(definitions),
+			DecodeHook:       DecodeHooksComposeAll(val, definitions),
 			Metadata:         nil,
 			Result:           o,
 			WeaklyTypedInput: true,
@@ -143,10 +154,36 @@ func unmarshal(ko *koanf.Koanf, val *schema.StructValidator, path string, o any,
 	}
 
 	if err := ko.UnmarshalWithConf(path, o, c); err != nil {
-		val.Push(fmt.Errorf("error occurred during unmarshaling configuration: %w", err))
+		for _, err = range flattenDecodeErrors(err) {
+			val.Push(fmt.Errorf("error occurred during unmarshaling configuration: %w", err))
+		}
 	}
 }
 
+// flattenDecodeErrors splits the joined errors returned by the decoder into the individual errors so each of them can
+// be reported separately, and converts each *mapstructure.DecodeError into a *DecodeKeyError which has the dotted path
+// of the key which could not be decoded.
+func flattenDecodeErrors(err error) (errs []error) {
+	switch e := err.(type) {
+	case *mapstructure.DecodeError:
+		return []error{&DecodeKeyError{Key: e.Name(), Err: e.Unwrap()}}
+	case interface{ Unwrap() []error }:
+		for _, err = range e.Unwrap() {
+			errs = append(errs, flattenDecodeErrors(err)...)
+		}
+
+		return errs
+	}
+
+	var joined interface{ Unwrap() []error }
+
+	if errors.As(err, &joined) {
+		return flattenDecodeErrors(joined.(error))
+	}
+
+	return []error{err}
+}
+
 func loadSources(ko *koanf.Koanf, val *schema.StructValidator, sources ...Source) (err error) {
 	if len(sources) == 0 {
 		return errNoSources
@@ -553,7 +553,7 @@ func TestShouldNotIgnoreInvalidEnvs(t *testing.T) {
 	assert.Len(t, val.Errors(), 1)
 
 	assert.EqualError(t, val.Warnings()[0], fmt.Sprintf("configuration environment variable not expected: %sSTORAGE_MYSQL", DefaultEnvPrefix))
-	assert.EqualError(t, val.Errors()[0], "error occurred during unmarshaling configuration: decoding failed due to the following error(s):\n\n'authentication_backend.ldap.address' could not decode 'an env authentication backend ldap password' to a *schema.AddressLDAP: could not parse string 'an env authentication backend ldap password' as address: expected format is [<scheme>://]<hostname>[:<port>]: parse \"ldaps://an env authentication backend ldap password\": invalid character \" \" in host name")
+	assert.EqualError(t, val.Errors()[0], "error occurred during unmarshaling configuration: the key 'authentication_backend.ldap.address' has an invalid value: could not decode 'an env authentication backend ldap password' to a *schema.AddressLDAP: could not parse string 'an env authentication backend ldap password' as address: expected format is [<scheme>://]<hostname>[:<port>]: parse \"ldaps://an env authentication backend ldap password\": invalid character \" \" in host name")
 }
 
 func TestShouldValidateServerAddressValues(t *testing.T) {
@@ -1084,7 +1084,7 @@ func TestShouldRaiseErrOnInvalidNotifierSMTPSender(t *testing.T) {
 	require.Len(t, val.Errors(), 1)
 	assert.Len(t, val.Warnings(), 0)
 
-	assert.EqualError(t, val.Errors()[0], "error occurred during unmarshaling configuration: decoding failed due to the following error(s):\n\n'notifier.smtp.sender' could not decode 'admin' to a mail.Address (RFC5322): mail: missing '@' or angle-addr")
+	assert.EqualError(t, val.Errors()[0], "error occurred during unmarshaling configuration: the key 'notifier.smtp.sender' has an invalid value: could not decode 'admin' to a mail.Address (RFC5322): mail: missing '@' or angle-addr")
 }
 
 func TestShouldHandleErrInvalidatorWhenSMTPSenderBlank(t *testing.T) {
@@ -1236,7 +1236,7 @@ func TestShouldErrOnParseInvalidRegex(t *testing.T) {
 	require.Len(t, val.Errors(), 1)
 	assert.Len(t, val.Warnings(), 0)
 
-	assert.EqualError(t, val.Errors()[0], "error occurred during unmarshaling configuration: decoding failed due to the following error(s):\n\n'access_control.rules[0].domain_regex[0]' could not decode '^\\K(public|public2).example.com$' to a regexp.Regexp: error parsing regexp: invalid escape sequence: `\\K`")
+	assert.EqualError(t, val.Errors()[0], "error occurred during unmarshaling configuration: the key 'access_control.rules[0].domain_regex[0]' has an invalid value: could not decode '^\\K(public|public2).example.com$' to a regexp.Regexp: error parsing regexp: invalid escape sequence: `\\K`")
 }
 
 func TestShouldNotReadConfigurationOnFSAccessDenied(t *testing.T) {
@@ -1274,6 +1274,23 @@ func TestShouldLoadDirectoryConfiguration(t *testing.T) {
 	assert.EqualError(t, val.Warnings()[0], "configuration keys 'server.host', 'server.port', and 'server.path' are deprecated in 4.38.0 and has been replaced by 'server.address' in the format of '[tcp[(4|6)]://]<hostname>[:<port>][/<path>]' or 'tcp[(4|6)://][hostname]:<port>[/<path>]': you are not required to make any changes as this has been automatically mapped for you to the value 'tcp://:9091/', but to stop this warning being logged you will need to adjust your configuration, and this configuration key and auto-mapping is likely to be removed in 5.0.0")
 }
 
+func TestShouldLoadEmptyPasswordResetCustomURL(t *testing.T) {
+	val := schema.NewStructValidator()
+
+	config := &schema.Configuration{}
+
+	_, err := LoadAdvanced(val, "", config, nil, NewBytesSource([]byte(`
+authentication_backend:
+  password_reset:
+    custom_url: ''
+`)))
+
+	require.NoError(t, err)
+	require.Len(t, val.Errors(), 0)
+
+	assert.Nil(t, config.AuthenticationBackend.PasswordReset.CustomURL)
+}
+
 func testSetEnv(t *testing.T, key, value string) {
 	t.Helper()
 
@@ -1351,6 +1368,216 @@ func TestShouldFailIfYmlIsInvalid(t *testing.T) {
 	assert.ErrorContains(t, val.Errors()[0], "unmarshal errors")
 }
 
+func TestShouldReportDecodeErrorsWithKeyPath(t *testing.T) {
+	val := schema.NewStructValidator()
+
+	config := &schema.Configuration{}
+
+	_, err := LoadAdvanced(val, "", config, nil, NewBytesSource([]byte(`
+access_control:
+  rules:
+    - domain: 'example.com'
+      policy: 'one_factor'
+    - domain_regex:
+        - '^example\.com$'
+        - '^\K$'
+      policy: 'one_factor'
+identity_providers:
+  oidc:
+    lifespans:
+      custom:
+        example:
+          access_token: 'abc'
+`)))
+
+	require.NoError(t, err)
+	require.Len(t, val.Errors(), 2)
+
+	errs := val.Errors()
+
+	sort.Slice(errs, func(i, j int) bool {
+		return errs[i].Error() < errs[j].Error()
+	})
+
+	assert.EqualError(t, errs[0], "error occurred during unmarshaling configuration: the key 'access_control.rules[1].domain_regex[1]' has an invalid value: could not decode '^\\K$' to a regexp.Regexp: error parsing regexp: invalid escape sequence: `\\K`")
+	assert.EqualError(t, errs[1], "error occurred during unmarshaling configuration: the key 'identity_providers.oidc.lifespans.custom[example].access_token' has an invalid value: could not decode 'abc' to a time.Duration: could not parse 'abc' as a duration: the duration must be one or more quantity and unit pairs such as '1y2M3d4h' where the unit is one of 'ns', 'us', 'ms', 's', 'm', 'h', 'd' (24h), 'w' (7d), 'M' (30d), or 'y' (365d)")
+
+	var errKey *DecodeKeyError
+
+	require.ErrorAs(t, errs[1], &errKey)
+	assert.Equal(t, "identity_providers.oidc.lifespans.custom[example].access_token", errKey.Key)
+}
+
+func TestShouldExpandEnvironmentVariablesInStringValues(t *testing.T) {
+	t.Setenv("SERVICES_SERVER", "10.10.10.10")
+	t.Setenv("ROOT_DOMAIN", "example.org")
+
+	dir := t.TempDir()
+
+	cfg := filepath.Join(dir, "config.yml")
+	require.NoError(t, testCreateFile(cfg, `
+theme: '${THEME:-dark}'
+notifier:
+  smtp:
+    address: 'smtp://${SERVICES_SERVER}:1025'
+    subject: '[Authelia] $${title}'
+access_control:
+  rules:
+    - domain: '*.${ROOT_DOMAIN}'
+      policy: 'one_factor'
+    - domain_regex:
+        - '^${ROOT_DOMAIN}$'
+      policy: 'one_factor'
+`, 0600))
+
+	val := schema.NewStructValidator()
+
+	config := &schema.Configuration{}
+
+	_, err := LoadAdvanced(val, "", config, nil, NewFilteredFileSource(cfg, NewExpandEnvValuesFileFilter()))
+
+	require.NoError(t, err)
+	require.Len(t, val.Errors(), 0)
+
+	assert.Equal(t, "dark", config.Theme)
+	assert.Equal(t, "smtp://10.10.10.10:1025", config.Notifier.SMTP.Address.String())
+	assert.Equal(t, "[Authelia] ${title}", config.Notifier.SMTP.Subject)
+
+	require.Len(t, config.AccessControl.Rules, 2)
+	assert.Equal(t, []string{"*.example.org"}, config.AccessControl.Rules[0].Domains)
+	require.Len(t, config.AccessControl.Rules[1].DomainsRegex, 1)
+	assert.Equal(t, "^example.org$", config.AccessControl.Rules[1].DomainsRegex[0].String())
+}
+
+func TestShouldNotExpandEnvironmentVariablesInStringValuesWithoutFilter(t *testing.T) {
+	t.Setenv("ROOT_DOMAIN", "example.org")
+
+	val := schema.NewStructValidator()
+
+	config := &schema.Configuration{}
+
+	_, err := LoadAdvanced(val, "", config, nil, NewBytesSource([]byte(`
+notifier:
+  smtp:
+    subject: '[Authelia] $${title} ${ROOT_DOMAIN}'
+`)))
+
+	require.NoError(t, err)
+	require.Len(t, val.Errors(), 0)
+
+	assert.Equal(t, "[Authelia] $${title} ${ROOT_DOMAIN}", config.Notifier.SMTP.Subject)
+}
+
+func TestShouldNotExpandUnsetEnvironmentVariablesWithoutDefault(t *testing.T) {
+	dir := t.TempDir()
+
+	cfg := filepath.Join(dir, "config.yml")
+	require.NoError(t, testCreateFile(cfg, "notifier:\n  smtp:\n    address: 'smtp://${AUTHELIA_TEST_UNSET_SERVER}:1025'\n", 0600))
+
+	val := schema.NewStructValidator()
+
+	config := &schema.Configuration{}
+
+	_, err := LoadAdvanced(val, "", config, nil, NewFilteredFileSource(cfg, NewExpandEnvValuesFileFilter()))
+
+	require.NoError(t, err)
+	require.Len(t, val.Errors(), 1)
+
+	assert.EqualError(t, val.Errors()[0], fmt.Sprintf("failed to load configuration from file path(%s) source: error occurred expanding the environment variables in the value of the key 'notifier.smtp.address': the environment variable 'AUTHELIA_TEST_UNSET_SERVER' is not set and the reference does not have a default", cfg))
+}
+
+func TestShouldNotExpandSecretEnvironmentVariables(t *testing.T) {
+	t.Setenv("AUTHELIA_NOTIFIER_SMTP_PASSWORD", "secret")
+
+	dir := t.TempDir()
+
+	cfg := filepath.Join(dir, "config.yml")
+	require.NoError(t, testCreateFile(cfg, "notifier:\n  smtp:\n    subject: '${AUTHELIA_NOTIFIER_SMTP_PASSWORD}'\n", 0600))
+
+	val := schema.NewStructValidator()
+
+	config := &schema.Configuration{}
+
+	_, err := LoadAdvanced(val, "", config, nil, NewFilteredFileSource(cfg, NewExpandEnvValuesFileFilter()))
+
+	require.NoError(t, err)
+	require.Len(t, val.Errors(), 1)
+
+	assert.EqualError(t, val.Errors()[0], fmt.Sprintf("failed to load configuration from file path(%s) source: error occurred expanding the environment variables in the value of the key 'notifier.smtp.subject': the environment variable 'AUTHELIA_NOTIFIER_SMTP_PASSWORD' may contain a secret and can't be referenced", cfg))
+}
+
+func TestLoadDefinitionsNetworkReferences(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     string
+		expected map[string][]string
+		err      string
+	}{
+		{
+			"ShouldResolveNested",
+			"definitions:\n  network:\n    a: ['b', '10.0.0.0/8']\n    b: ['c', '192.168.1.0/24']\n    c: '172.16.0.0/12'\n",
+			map[string][]string{
+				"a": {"172.16.0.0/12", "192.168.1.0/24", "10.0.0.0/8"},
+				"b": {"172.16.0.0/12", "192.168.1.0/24"},
+				"c": {"172.16.0.0/12"},
+			},
+			"",
+		},
+		{
+			"ShouldErrorSelfReference",
+			"definitions:\n  network:\n    a: ['a', '10.0.0.0/8']\n",
+			nil,
+			"error occurred during unmarshaling definitions configuration: the network definition 'a' has a cyclic reference: a -> a",
+		},
+		{
+			"ShouldErrorTwoNodeCycle",
+			"definitions:\n  network:\n    a: ['b', '10.0.0.0/8']\n    b: ['a']\n",
+			nil,
+			"error occurred during unmarshaling definitions configuration: the network definition 'a' has a cyclic reference: a -> b -> a",
+		},
+		{
+			"ShouldErrorMaximumDepth",
+			"definitions:\n  network:\n    a0: 'a1'\n    a1: 'a2'\n    a2: 'a3'\n    a3: 'a4'\n    a4: 'a5'\n    a5: 'a6'\n    a6: 'a7'\n    a7: 'a8'\n    a8: 'a9'\n    a9: 'a10'\n    a10: '10.0.0.0/8'\n",
+			nil,
+			"error occurred during unmarshaling definitions configuration: the network definition 'a0' exceeds the maximum reference depth of 10: a0 -> a1 -> a2 -> a3 -> a4 -> a5 -> a6 -> a7 -> a8 -> a9 -> a10",
+		},
+		{
+			"ShouldErrorBadNetwork",
+			"definitions:\n  network:\n    a: ['b']\n    b: ['abc']\n",
+			nil,
+			"error occurred during unmarshaling definitions configuration: the network definition 'b' has a value which failed to parse as a network \"abc\": invalid CIDR address: abc",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			val := schema.NewStructValidator()
+
+			definitions, err := LoadDefinitions(val, NewBytesSource([]byte(tc.have)))
+
+			require.NoError(t, err)
+			require.NotNil(t, definitions)
+
+			if tc.err == "" {
+				assert.Len(t, val.Errors(), 0)
+
+				actual := make(map[string][]string, len(definitions.Network))
+
+				for name, networks := range definitions.Network {
+					for _, network := range networks {
+						actual[name] = append(actual[name], network.String())
+					}
+				}
+
+				assert.Equal(t, tc.expected, actual)
+			} else {
+				require.Len(t, val.Errors(), 1)
+				assert.EqualError(t, val.Errors()[0], tc.err)
+			}
+		})
+	}
+}
+
 func TestConfigurationDefinitions(t *testing.T) {
 	var (
 		definitions *schema.Definitions
@@ -121,15 +121,322 @@ var (
 	regexpHasScheme = regexp.MustCompile(`^[-+.a-zA-Z\d]*(://|:$)`)
 
 	regexpIsUmask = regexp.MustCompile(`^[0-7]{3,4}$`)
+
+	// regexpAddressPortRange checks if the authority of an address has a port range.
+	regexpAddressPortRange = regexp.MustCompile(`^(.*):(\d+)-(\d+)$`)
+
+	regexpByteSize = regexp.MustCompile(`^(\d+)(?:\.(\d+))?\s*([a-zA-Z]*)$`)
+
+	// regexpIsHostname checks if a string is a syntactically valid DNS name. Underscores are permitted as they're
+	// commonly used for internal service names.
+	regexpIsHostname = regexp.MustCompile(`^[a-zA-Z0-9_]([a-zA-Z0-9_-]{0,61}[a-zA-Z0-9_])?(\.[a-zA-Z0-9_]([a-zA-Z0-9_-]{0,61}[a-zA-Z0-9_])?)*\.?$`)
 )
 
 const (
 	policyTwoFactor = "two_factor"
 )
 
+// Event Names.
+const (
+	EventNameLoginSuccess          EventName = "login.success"
+	EventNameLoginFailure          EventName = "login.failure"
+	EventNameLogout                EventName = "logout"
+	EventNameSecondFactorSuccess   EventName = "second_factor.success"
+	EventNameSecondFactorFailure   EventName = "second_factor.failure"
+	EventNamePasswordResetComplete EventName = "password_reset.complete"
+	EventNameUserCreated           EventName = "user.created"
+	EventNameUserUpdated           EventName = "user.updated"
+	EventNameUserDeleted           EventName = "user.deleted"
+
+	// EventNameWildcard represents all of the EventNames.
+	EventNameWildcard = "*"
+)
+
+var (
+	// EventNames is the catalog of all known EventName's.
+	EventNames = []EventName{
+		EventNameLoginSuccess,
+		EventNameLoginFailure,
+		EventNameLogout,
+		EventNameSecondFactorSuccess,
+		EventNameSecondFactorFailure,
+		EventNamePasswordResetComplete,
+		EventNameUserCreated,
+		EventNameUserUpdated,
+		EventNameUserDeleted,
+	}
+)
+
+// MFA Methods.
+const (
+	MFAMethodTOTP       MFAMethod = "totp"
+	MFAMethodWebAuthn   MFAMethod = "webauthn"
+	MFAMethodMobilePush MFAMethod = "mobile_push"
+)
+
+var (
+	// MFAMethods is the catalog of all known MFAMethod's.
+	//
+	// IMPORTANT: This is a copy of validDefault2FAMethods in github.com/authelia/authelia/internal/configuration/validator.
+	// Make sure you update these at the same time.
+	MFAMethods = []MFAMethod{
+		MFAMethodTOTP,
+		MFAMethodWebAuthn,
+		MFAMethodMobilePush,
+	}
+)
+
+// JSON Web Token Algorithms.
+const (
+	JWTAlgorithmHS256 JWTAlgorithm = "HS256"
+	JWTAlgorithmHS384 JWTAlgorithm = "HS384"
+	JWTAlgorithmHS512 JWTAlgorithm = "HS512"
+	JWTAlgorithmRS256 JWTAlgorithm = "RS256"
+	JWTAlgorithmRS384 JWTAlgorithm = "RS384"
+	JWTAlgorithmRS512 JWTAlgorithm = "RS512"
+	JWTAlgorithmES256 JWTAlgorithm = "ES256"
+	JWTAlgorithmES384 JWTAlgorithm = "ES384"
+	JWTAlgorithmES512 JWTAlgorithm = "ES512"
+	JWTAlgorithmPS256 JWTAlgorithm = "PS256"
+	JWTAlgorithmPS384 JWTAlgorithm = "PS384"
+	JWTAlgorithmPS512 JWTAlgorithm = "PS512"
+)
+
+var (
+	// JWTAlgorithms is the catalog of all known JWTAlgorithm's.
+	JWTAlgorithms = []JWTAlgorithm{
+		JWTAlgorithmHS256, JWTAlgorithmHS384, JWTAlgorithmHS512,
+		JWTAlgorithmRS256, JWTAlgorithmRS384, JWTAlgorithmRS512,
+		JWTAlgorithmES256, JWTAlgorithmES384, JWTAlgorithmES512,
+		JWTAlgorithmPS256, JWTAlgorithmPS384, JWTAlgorithmPS512,
+	}
+)
+
+// JSON Web Token Algorithm Uses.
+const (
+	JWTAlgorithmUseIDToken       = "id_token"
+	JWTAlgorithmUseUserInfo      = "userinfo"
+	JWTAlgorithmUseRequestObject = "request_object"
+	JWTAlgorithmUseIntrospection = "introspection"
+	JWTAlgorithmUseAuthorization = "authorization"
+	JWTAlgorithmUseAccessToken   = "access_token"
+)
+
+var (
+	// JWTAlgorithmUses is the catalog of all known uses of a JWTAlgorithm.
+	JWTAlgorithmUses = []string{
+		JWTAlgorithmUseIDToken,
+		JWTAlgorithmUseUserInfo,
+		JWTAlgorithmUseRequestObject,
+		JWTAlgorithmUseIntrospection,
+		JWTAlgorithmUseAuthorization,
+		JWTAlgorithmUseAccessToken,
+	}
+)
+
+// Schedule Windows.
+const (
+	ScheduleWindowPeak     = "peak"
+	ScheduleWindowOffPeak  = "offpeak"
+	ScheduleWindowBusiness = "business"
+	ScheduleWindowWeekend  = "weekend"
+	ScheduleWindowNight    = "night"
+)
+
+var (
+	// ScheduleWindows is the catalog of all known schedule window labels.
+	ScheduleWindows = []string{
+		ScheduleWindowPeak,
+		ScheduleWindowOffPeak,
+		ScheduleWindowBusiness,
+		ScheduleWindowWeekend,
+		ScheduleWindowNight,
+	}
+)
+
+// Notification Channel Types.
+const (
+	NotificationChannelTypeSMTP    = "smtp"
+	NotificationChannelTypeWebhook = "webhook"
+)
+
+var (
+	// NotificationChannelTypes is the catalog of all known notification channel types.
+	NotificationChannelTypes = []string{
+		NotificationChannelTypeSMTP,
+		NotificationChannelTypeWebhook,
+	}
+)
+
+// Session Crypto Algorithms.
+const (
+	SessionCryptoAlgorithmAESGCM            = "aes-gcm"
+	SessionCryptoAlgorithmChaCha20Poly1305  = "chacha20-poly1305"
+	SessionCryptoAlgorithmXChaCha20Poly1305 = "xchacha20-poly1305"
+)
+
+var (
+	// SessionCryptoAlgorithms is the catalog of all known session encryption algorithms.
+	SessionCryptoAlgorithms = []string{
+		SessionCryptoAlgorithmAESGCM,
+		SessionCryptoAlgorithmChaCha20Poly1305,
+		SessionCryptoAlgorithmXChaCha20Poly1305,
+	}
+)
+
+// Consent Scope Categories.
+const (
+	ConsentScopeCategoryRequired      = "required"
+	ConsentScopeCategoryPreAuthorized = "preauthorized"
+)
+
+var (
+	// ConsentScopeCategories is the catalog of all known consent scope categories.
+	ConsentScopeCategories = []string{
+		ConsentScopeCategoryRequired,
+		ConsentScopeCategoryPreAuthorized,
+	}
+)
+
+// Audit Sink Types.
+const (
+	AuditSinkTypeFile   = "file"
+	AuditSinkTypeSyslog = "syslog"
+)
+
+var (
+	// AuditSinkTypes is the catalog of all known audit log sink types.
+	AuditSinkTypes = []string{
+		AuditSinkTypeFile,
+		AuditSinkTypeSyslog,
+	}
+)
+
+var (
+	// OIDCStandardClaims is the catalog of claims which may be released to OpenID Connect 1.0 clients.
+	//
+	// IMPORTANT: This is a copy of validOIDCClientClaims in github.com/authelia/authelia/internal/configuration/validator.
+	// Make sure you update these at the same time.
+	OIDCStandardClaims = []string{
+		"name", "given_name", "family_name", "middle_name", "nickname", "preferred_username", "profile", "picture",
+		"website", "email", "email_verified", "gender", "birthdate", "zoneinfo", "locale", "phone_number",
+		"phone_number_verified", "address", "groups", "alt_emails", "rat", "updated_at",
+	}
+)
+
+// Cache Backends.
+const (
+	CacheBackendRedis    = "redis"
+	CacheBackendRedisTLS = "rediss"
+	CacheBackendMemory   = "memory"
+)
+
+var (
+	// CacheBackends is the catalog of all known cache backends.
+	CacheBackends = []string{
+		CacheBackendRedis,
+		CacheBackendRedisTLS,
+		CacheBackendMemory,
+	}
+)
+
+const (
+	cacheDSNQueryParamPool = "pool"
+	cacheDSNRedisPort      = "6379"
+)
+
+// GeoIP Providers.
 const (
-	addressQueryParamUmask = "umask"
-	addressQueryParamPath  = "path"
+	GeoIPProviderMaxMind     = "maxmind"
+	GeoIPProviderDBIP        = "dbip"
+	GeoIPProviderIP2Location = "ip2location"
+)
+
+var (
+	// GeoIPProviders is the catalog of all known GeoIP database providers.
+	GeoIPProviders = []string{
+		GeoIPProviderMaxMind,
+		GeoIPProviderDBIP,
+		GeoIPProviderIP2Location,
+	}
+)
+
+// Rate Limit Subjects.
+const (
+	RateLimitSubjectUser   = "user"
+	RateLimitSubjectIP     = "ip"
+	RateLimitSubjectClient = "client"
+)
+
+var (
+	// RateLimitSubjects is the catalog of all known rate limit subject types.
+	RateLimitSubjects = []string{
+		RateLimitSubjectUser,
+		RateLimitSubjectIP,
+		RateLimitSubjectClient,
+	}
+)
+
+// Environments.
+const (
+	EnvironmentDevelopment = "dev"
+	EnvironmentTest        = "test"
+	EnvironmentStaging     = "staging"
+	EnvironmentProduction  = "prod"
+)
+
+var (
+	// Environments is the catalog of all known environment names.
+	Environments = []string{
+		EnvironmentDevelopment,
+		EnvironmentTest,
+		EnvironmentStaging,
+		EnvironmentProduction,
+	}
+)
+
+var (
+	// byteSizeUnits maps the lowercase byte size units to their multiplier. The single letter and IEC units are binary
+	// multiples, whereas the SI units are decimal multiples.
+	byteSizeUnits = map[string]uint64{
+		"":    1,
+		"b":   1,
+		"k":   1 << 10,
+		"kib": 1 << 10,
+		"kb":  1000,
+		"m":   1 << 20,
+		"mib": 1 << 20,
+		"mb":  1000 * 1000,
+		"g":   1 << 30,
+		"gib": 1 << 30,
+		"gb":  1000 * 1000 * 1000,
+	}
+)
+
+const (
+	addressQueryParamUmask     = "umask"
+	addressQueryParamPath      = "path"
+	addressQueryParamBacklog   = "backlog"
+	addressQueryParamSndBuf    = "sndbuf"
+	addressQueryParamRcvBuf    = "rcvbuf"
+	addressQueryParamTFO       = "tfo"
+	addressQueryParamNoDelay   = "nodelay"
+	addressQueryParamLocalAddr = "local_addr"
+	addressQueryParamDualStack = "dualstack"
+	addressQueryParamFamily    = "family"
+	addressQueryParamReusePort = "reuseport"
+	addressQueryParamDSCP      = "dscp"
+
+	addressQueryParamConnMaxLifetime = "conn_max_lifetime"
+)
+
+const (
+	addressDualStackOn  = "on"
+	addressDualStackOff = "off"
+
+	addressFamilyDual     = "dual"
+	addressFamilyIPv4Only = "ipv4-only"
+	addressFamilyIPv6Only = "ipv6-only"
 )
 
 const (
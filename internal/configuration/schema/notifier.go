@@ -42,9 +42,18 @@ type NotifierSMTP struct {
 	Port int `koanf:"port" yaml:"port" toml:"port" json:"port" jsonschema:"deprecated"`
 }
 
+// NotificationChannel represents a single channel of a multi-channel notifier. It's typically decoded from the compact
+// inline string form.
+type NotificationChannel struct {
+	Type    string        `koanf:"type" yaml:"type" toml:"type" json:"type" jsonschema:"enum=smtp,enum=webhook,title=Type" jsonschema_description:"The type of notification channel."`
+	Address *AddressSMTP  `koanf:"address" yaml:"address,omitempty" toml:"address,omitempty" json:"address,omitempty" jsonschema:"title=Address" jsonschema_description:"The SMTP server address for the smtp channel type."`
+	From    *mail.Address `koanf:"from" yaml:"from,omitempty" toml:"from,omitempty" json:"from,omitempty" jsonschema:"title=From" jsonschema_description:"The sender for the smtp channel type."`
+	URL     *url.URL      `koanf:"url" yaml:"url,omitempty" toml:"url,omitempty" json:"url,omitempty" jsonschema:"title=URL" jsonschema_description:"The URL for the webhook channel type."`
+}
+
 // DefaultSMTPNotifierConfiguration represents default configuration parameters for the SMTP notifier.
 var DefaultSMTPNotifierConfiguration = NotifierSMTP{
-	Address:             &AddressSMTP{Address{true, false, -1, 25, nil, &url.URL{Scheme: AddressSchemeSMTP, Host: "localhost:25"}}},
+	Address:             &AddressSMTP{Address{true, false, -1, 25, 0, nil, 0, &url.URL{Scheme: AddressSchemeSMTP, Host: "localhost:25"}}},
 	Timeout:             time.Second * 5,
 	Subject:             "[Authelia] {title}",
 	Identifier:          "localhost",
@@ -1,6 +1,7 @@
 package schema
 
 import (
+	"net"
 	"net/url"
 	"time"
 )
@@ -55,6 +56,13 @@ type ServerHeaders struct {
 	CSPTemplate CSPTemplate `koanf:"csp_template" yaml:"csp_template,omitempty" toml:"csp_template,omitempty" json:"csp_template,omitempty" jsonschema:"title=CSP Template" jsonschema_description:"The Content Security Policy template."`
 }
 
+// ForwardedTrust represents the trust configuration for the X-Forwarded-For header. It's typically decoded from the
+// compact inline string form.
+type ForwardedTrust struct {
+	Hops     int          `koanf:"hops" yaml:"hops" toml:"hops" json:"hops" jsonschema:"default=0,title=Hops" jsonschema_description:"The number of trusted hops to skip from the end of the header."`
+	Networks []*net.IPNet `koanf:"networks" yaml:"networks,omitempty" toml:"networks,omitempty" json:"networks,omitempty" jsonschema:"title=Networks" jsonschema_description:"The networks which are trusted to set the header."`
+}
+
 type ServerEndpointRateLimits struct {
 	ResetPasswordStart                      ServerEndpointRateLimit `koanf:"reset_password_start" yaml:"reset_password_start,omitempty" toml:"reset_password_start,omitempty" json:"reset_password_start,omitempty" jsonschema:"title=Reset Password Start" jsonschema_description:"Configures the rate limiter which applies to the endpoint that initializes the reset password flow."`
 	ResetPasswordFinish                     ServerEndpointRateLimit `koanf:"reset_password_finish" yaml:"reset_password_finish,omitempty" toml:"reset_password_finish,omitempty" json:"reset_password_finish,omitempty" jsonschema:"title=Reset Password Finish" jsonschema_description:"Configures the rate limiter which applies to endpoints which consume tokens for the reset password flow."`
@@ -81,7 +89,7 @@ type ServerEndpointRateLimitBucket struct {
 
 // DefaultServerConfiguration represents the default values of the Server.
 var DefaultServerConfiguration = Server{
-	Address: &AddressTCP{Address{true, false, -1, 9091, nil, &url.URL{Scheme: AddressSchemeTCP, Host: ":9091", Path: "/"}}},
+	Address: &AddressTCP{Address{true, false, -1, 9091, 0, nil, 0, &url.URL{Scheme: AddressSchemeTCP, Host: ":9091", Path: "/"}}},
 	Buffers: ServerBuffers{
 		Read:  4096,
 		Write: 4096,
@@ -1,6 +1,8 @@
 package schema
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"time"
 )
 
@@ -16,6 +18,42 @@ type TLS struct {
 	CertificateChain X509CertificateChain    `koanf:"certificate_chain" yaml:"certificate_chain,omitempty" toml:"certificate_chain,omitempty" json:"certificate_chain,omitempty" jsonschema:"title=Certificate Chain" jsonschema_description:"The certificate chain."`
 }
 
+// NewTLSConfig generates a tls.Config from a TLS and a x509.CertPool.
+func NewTLSConfig(config *TLS, rootCAs *x509.CertPool) (tlsConfig *tls.Config) {
+	if config == nil {
+		return nil
+	}
+
+	var certificates []tls.Certificate
+
+	if config.PrivateKey != nil && config.CertificateChain.HasCertificates() {
+		certificates = []tls.Certificate{
+			{
+				Certificate: config.CertificateChain.CertificatesRaw(),
+				Leaf:        config.CertificateChain.Leaf(),
+				PrivateKey:  config.PrivateKey,
+			},
+		}
+	}
+
+	return &tls.Config{
+		ServerName:         config.ServerName,
+		InsecureSkipVerify: config.SkipVerify, //nolint:gosec // Informed choice by user. Off by default.
+		MinVersion:         config.MinimumVersion.MinVersion(),
+		MaxVersion:         config.MaximumVersion.MaxVersion(),
+		RootCAs:            rootCAs,
+		Certificates:       certificates,
+	}
+}
+
+// TLSConfig represents a TLS configuration which also includes the certificate authorities trusted to verify the peer.
+// It's typically decoded from the compact inline string form.
+type TLSConfig struct {
+	TLS `koanf:",squash"`
+
+	CertificateAuthorities X509CertificateChain `koanf:"certificate_authorities" yaml:"certificate_authorities,omitempty" toml:"certificate_authorities,omitempty" json:"certificate_authorities,omitempty" jsonschema:"title=Certificate Authorities" jsonschema_description:"The certificate authorities trusted to verify the peer certificate."`
+}
+
 // ServerTimeouts represents server timeout configurations.
 type ServerTimeouts struct {
 	Read  time.Duration `koanf:"read" yaml:"read,omitempty" toml:"read,omitempty" json:"read,omitempty" jsonschema:"default=6 seconds,title=Read" jsonschema_description:"The read timeout."`
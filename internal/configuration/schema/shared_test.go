@@ -0,0 +1,83 @@
+package schema
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/utils"
+)
+
+func TestNewTLSConfig(t *testing.T) {
+	sys, err := x509.SystemCertPool()
+	require.NoError(t, err)
+
+	rawKey, err := os.ReadFile(filepath.Join("..", "test_resources", "crypto", "rsa.2048.pem"))
+	require.NoError(t, err)
+
+	keyAny, err := utils.ParseX509FromPEM(rawKey)
+	require.NoError(t, err)
+
+	key, ok := keyAny.(*rsa.PrivateKey)
+	require.True(t, ok)
+
+	rawCert, err := os.ReadFile(filepath.Join("..", "test_resources", "crypto", "rsa.2048.crt"))
+	require.NoError(t, err)
+
+	chain, err := NewX509CertificateChain(string(rawCert))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name   string
+		have   *TLS
+		pool   *x509.CertPool
+		expect bool
+	}{
+		{
+			"ShouldHandleNil",
+			nil,
+			nil,
+			false,
+		},
+		{
+			"ShouldHandleStandard",
+			&TLS{},
+			sys,
+			true,
+		},
+		{
+			"ShouldHandleKeySolo",
+			&TLS{
+				PrivateKey: key,
+			},
+			sys,
+			true,
+		},
+		{
+			"ShouldHandleKey",
+			&TLS{
+				PrivateKey:       key,
+				CertificateChain: *chain,
+			},
+			sys,
+			true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := NewTLSConfig(tc.have, tc.pool)
+
+			if tc.expect {
+				assert.NotNil(t, actual)
+			} else {
+				assert.Nil(t, actual)
+			}
+		})
+	}
+}
@@ -68,7 +68,7 @@ var DefaultSQLStorageConfiguration = StorageSQL{
 // DefaultMySQLStorageConfiguration represents the default MySQL configuration.
 var DefaultMySQLStorageConfiguration = StorageMySQL{
 	StorageSQL: StorageSQL{
-		Address: &AddressTCP{Address{true, false, -1, 3306, nil, &url.URL{Scheme: AddressSchemeTCP, Host: "localhost:3306"}}},
+		Address: &AddressTCP{Address{true, false, -1, 3306, 0, nil, 0, &url.URL{Scheme: AddressSchemeTCP, Host: "localhost:3306"}}},
 		TLS: &TLS{
 			MinimumVersion: TLSVersion{tls.VersionTLS12},
 		},
@@ -78,14 +78,14 @@ var DefaultMySQLStorageConfiguration = StorageMySQL{
 // DefaultPostgreSQLStorageConfiguration represents the default PostgreSQL configuration.
 var DefaultPostgreSQLStorageConfiguration = StoragePostgreSQL{
 	StorageSQL: StorageSQL{
-		Address: &AddressTCP{Address{true, false, -1, 5432, nil, &url.URL{Scheme: AddressSchemeTCP, Host: "localhost:5432"}}},
+		Address: &AddressTCP{Address{true, false, -1, 5432, 0, nil, 0, &url.URL{Scheme: AddressSchemeTCP, Host: "localhost:5432"}}},
 		TLS: &TLS{
 			MinimumVersion: TLSVersion{tls.VersionTLS12},
 		},
 	},
 	Servers: []StoragePostgreSQLServer{
 		{
-			Address: &AddressTCP{Address{true, false, -1, 5432, nil, &url.URL{Scheme: AddressSchemeTCP, Host: "localhost:5432"}}},
+			Address: &AddressTCP{Address{true, false, -1, 5432, 0, nil, 0, &url.URL{Scheme: AddressSchemeTCP, Host: "localhost:5432"}}},
 			TLS: &TLS{
 				MinimumVersion: TLSVersion{tls.VersionTLS12},
 			},
@@ -11,15 +11,24 @@ import (
 	"encoding/pem"
 	"errors"
 	"fmt"
+	"image/color"
+	"math"
+	"math/big"
+	"net"
+	"net/url"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/go-crypt/crypt"
 	"github.com/go-crypt/crypt/algorithm"
 	"github.com/go-crypt/crypt/algorithm/plaintext"
 	"github.com/valyala/fasthttp"
 	"go.yaml.in/yaml/v4"
+	"golang.org/x/net/idna"
+	"golang.org/x/text/language"
 
 	"github.com/authelia/jsonschema"
 )
@@ -184,24 +193,68 @@ func NewX509CertificateChainFromCerts(in []*x509.Certificate) (chain X509Certifi
 	return X509CertificateChain{certs: in}
 }
 
-// NewTLSVersion returns a new TLSVersion given a string.
+// NewTLSVersion returns a new TLSVersion given a string. The string may be the name of the version such as 'TLS1.2',
+// 'TLS 1.2', or 'TLSv1.2', the version number such as '1.2', or the decimal or hexadecimal code point used by crypto/tls
+// such as '771' or '0x0303'. Versions below the minimum supported version return an error which includes the lowest
+// allowed version.
 func NewTLSVersion(input string) (version *TLSVersion, err error) {
-	switch strings.ReplaceAll(strings.ToUpper(input), " ", "") {
-	case TLSVersion13, Version13, tls.VersionName(tls.VersionTLS13):
+	value := strings.ToUpper(strings.ReplaceAll(input, " ", ""))
+
+	var code uint64
+
+	switch {
+	case strings.HasPrefix(value, "0X"):
+		if code, err = strconv.ParseUint(value[2:], 16, 16); err != nil {
+			return nil, ErrTLSVersionNotSupported
+		}
+
+		return newTLSVersionFromCode(input, uint16(code))
+	case strings.HasPrefix(value, "SSL"):
+		switch strings.TrimPrefix(strings.TrimPrefix(value, "SSL"), "V") {
+		case "3", "3.0":
+			return &TLSVersion{tls.VersionSSL30}, nil //nolint:staticcheck
+		case "2", "2.0":
+			return nil, newTLSVersionBelowMinimumError(input)
+		}
+
+		return nil, ErrTLSVersionNotSupported
+	}
+
+	if code, err = strconv.ParseUint(value, 10, 16); err == nil {
+		return newTLSVersionFromCode(input, uint16(code))
+	}
+
+	switch strings.TrimPrefix(strings.TrimPrefix(value, "TLS"), "V") {
+	case Version13:
 		return &TLSVersion{tls.VersionTLS13}, nil
-	case TLSVersion12, Version12, tls.VersionName(tls.VersionTLS12):
+	case Version12:
 		return &TLSVersion{tls.VersionTLS12}, nil
-	case TLSVersion11, Version11, tls.VersionName(tls.VersionTLS11):
+	case Version11:
 		return &TLSVersion{tls.VersionTLS11}, nil
-	case TLSVersion10, Version10, tls.VersionName(tls.VersionTLS10):
+	case Version10:
 		return &TLSVersion{tls.VersionTLS10}, nil
-	case SSLVersion30, strings.ToUpper(tls.VersionName(tls.VersionSSL30)): //nolint:staticcheck
-		return &TLSVersion{tls.VersionSSL30}, nil //nolint:staticcheck
 	}
 
 	return nil, ErrTLSVersionNotSupported
 }
 
+func newTLSVersionFromCode(input string, code uint16) (version *TLSVersion, err error) {
+	switch code {
+	case tls.VersionTLS13, tls.VersionTLS12, tls.VersionTLS11, tls.VersionTLS10, tls.VersionSSL30: //nolint:staticcheck
+		return &TLSVersion{code}, nil
+	}
+
+	if code < tls.VersionSSL30 { //nolint:staticcheck
+		return nil, newTLSVersionBelowMinimumError(input)
+	}
+
+	return nil, ErrTLSVersionNotSupported
+}
+
+func newTLSVersionBelowMinimumError(input string) error {
+	return fmt.Errorf("%w: the version '%s' is below the minimum supported version so the lowest allowed version is '%s'", ErrTLSVersionNotSupported, input, SSLVersion30)
+}
+
 // TLSVersion is a struct which handles tls.Config versions.
 type TLSVersion struct {
 	Value uint16
@@ -432,6 +485,24 @@ func (c *X509CertificateChain) Validate() (err error) {
 	return nil
 }
 
+// ValidateOrder ensures the certificates of the X509CertificateChain were provided in the correct order with the nth
+// being issued and signed by the nth+1. Unlike Validate it does not check the validity period of the certificates.
+func (c *X509CertificateChain) ValidateOrder() (err error) {
+	for i := 0; i+1 < len(c.certs); i++ {
+		cert, next := c.certs[i], c.certs[i+1]
+
+		if !bytes.Equal(cert.RawIssuer, next.RawSubject) {
+			return fmt.Errorf("certificate #%d in chain is not issued by certificate #%d in chain: the issuer '%s' does not match the subject '%s'", i+1, i+2, cert.Issuer, next.Subject)
+		}
+
+		if err = cert.CheckSignatureFrom(next); err != nil {
+			return fmt.Errorf("certificate #%d in chain is not signed properly by certificate #%d in chain: %w", i+1, i+2, err)
+		}
+	}
+
+	return nil
+}
+
 // NewRefreshIntervalDuration returns a RefreshIntervalDuration given a time.Duration.
 func NewRefreshIntervalDuration(value time.Duration) RefreshIntervalDuration {
 	return RefreshIntervalDuration{value: value, valid: true}
@@ -501,6 +572,574 @@ func (RefreshIntervalDuration) JSONSchema() *jsonschema.Schema {
 	}
 }
 
+// EventName represents the name of an event which can be subscribed to.
+type EventName string
+
+// MFAMethod represents the name of a multi-factor authentication method.
+type MFAMethod string
+
+// ParseByteSize parses a byte size string such as '4096', '64k', '4MiB', or '1MB' into the number of bytes. The units
+// are case-insensitive, the single letter units 'k', 'm', and 'g' and the IEC units 'KiB', 'MiB', and 'GiB' are binary
+// multiples, and the SI units 'KB', 'MB', and 'GB' are decimal multiples.
+func ParseByteSize(value string) (size uint64, err error) {
+	return parseByteSize(value, false)
+}
+
+// NewByteSize parses a byte size string in the same way as ParseByteSize into a ByteSize. Unlike ParseByteSize the
+// value may be fractional such as '1.5GB' provided it resolves to a whole number of bytes, and it must not exceed the
+// maximum value of an int64.
+func NewByteSize(value string) (size ByteSize, err error) {
+	if strings.HasPrefix(strings.TrimSpace(value), "-") {
+		return 0, fmt.Errorf("could not parse '%s' as a byte size: the value must not be negative", value)
+	}
+
+	var n uint64
+
+	if n, err = parseByteSize(value, true); err != nil {
+		return 0, err
+	}
+
+	if n > math.MaxInt64 {
+		return 0, fmt.Errorf("could not parse '%s' as a byte size: the value is too large", value)
+	}
+
+	return ByteSize(n), nil
+}
+
+func parseByteSize(value string, fractional bool) (size uint64, err error) {
+	matches := regexpByteSize.FindStringSubmatch(strings.TrimSpace(value))
+
+	switch {
+	case fractional && matches == nil:
+		return 0, fmt.Errorf("could not parse '%s' as a byte size: must be a positive number optionally followed by a unit", value)
+	case matches == nil, !fractional && matches[2] != "":
+		return 0, fmt.Errorf("could not parse '%s' as a byte size: must be a positive integer optionally followed by a unit", value)
+	}
+
+	multiplier, ok := byteSizeUnits[strings.ToLower(matches[3])]
+	if !ok {
+		return 0, fmt.Errorf("could not parse '%s' as a byte size: the unit '%s' is not valid", value, matches[3])
+	}
+
+	if matches[2] != "" {
+		n, _ := new(big.Int).SetString(matches[1]+matches[2], 10)
+
+		n.Mul(n, new(big.Int).SetUint64(multiplier))
+
+		q, r := n.QuoRem(n, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(len(matches[2]))), nil), new(big.Int))
+
+		switch {
+		case r.Sign() != 0:
+			return 0, fmt.Errorf("could not parse '%s' as a byte size: the value does not resolve to a whole number of bytes", value)
+		case !q.IsUint64():
+			return 0, fmt.Errorf("could not parse '%s' as a byte size: the value is too large", value)
+		}
+
+		return q.Uint64(), nil
+	}
+
+	if size, err = strconv.ParseUint(matches[1], 10, 64); err != nil {
+		return 0, fmt.Errorf("could not parse '%s' as a byte size: %w", value, err)
+	}
+
+	if size > math.MaxUint64/multiplier {
+		return 0, fmt.Errorf("could not parse '%s' as a byte size: the value is too large", value)
+	}
+
+	return size * multiplier, nil
+}
+
+// NewCacheDSN parses a cache DSN such as 'redis://:password@redis:6379/0?pool=20' or 'memory://' into a *CacheDSN. The
+// 'redis' and 'rediss' backends require a host, default to port 6379, and may specify the database index as the path
+// and the pool size as the 'pool' option. The 'memory' backend doesn't accept any other values.
+//
+//nolint:gocyclo // This is an adequately clear function even with the complexity.
+func NewCacheDSN(value string) (dsn *CacheDSN, err error) {
+	var u *url.URL
+
+	if u, err = url.Parse(value); err != nil {
+		return nil, fmt.Errorf("could not parse the dsn: %w", err)
+	}
+
+	redacted := u.Redacted()
+
+	dsn = &CacheDSN{Backend: u.Scheme}
+
+	switch u.Scheme {
+	case CacheBackendMemory:
+		if u.Host != "" || u.User != nil || (u.Path != "" && u.Path != "/") || u.RawQuery != "" {
+			return nil, fmt.Errorf("the dsn '%s' has a host, credentials, database, or options but these are not valid for the '%s' backend", redacted, u.Scheme)
+		}
+
+		return dsn, nil
+	case CacheBackendRedis, CacheBackendRedisTLS:
+		break
+	default:
+		return nil, fmt.Errorf("the dsn '%s' has the backend '%s' but it must be one of 'redis', 'rediss', or 'memory'", redacted, u.Scheme)
+	}
+
+	if u.Hostname() == "" {
+		return nil, fmt.Errorf("the dsn '%s' does not have a host but it's required for the '%s' backend", redacted, u.Scheme)
+	}
+
+	host := u.Host
+
+	if u.Port() == "" {
+		host = net.JoinHostPort(u.Hostname(), cacheDSNRedisPort)
+	}
+
+	var address *Address
+
+	if address, err = NewAddressFromURL(&url.URL{Scheme: AddressSchemeTCP, Host: host}); err != nil {
+		return nil, fmt.Errorf("the dsn '%s' does not have a valid address: %w", redacted, err)
+	}
+
+	dsn.Address = &AddressTCP{Address: *address}
+
+	if u.User != nil {
+		dsn.Username = u.User.Username()
+		dsn.Password, _ = u.User.Password()
+	}
+
+	if database := strings.TrimPrefix(u.Path, "/"); database != "" {
+		if dsn.Database, err = strconv.Atoi(database); err != nil || dsn.Database < 0 {
+			return nil, fmt.Errorf("the dsn '%s' has the database index '%s' but it must be a positive integer", redacted, database)
+		}
+	}
+
+	query := u.Query()
+
+	for key := range query {
+		if key != cacheDSNQueryParamPool {
+			return nil, fmt.Errorf("the dsn '%s' has the option '%s' but it is unknown and must be one of '%s'", redacted, key, cacheDSNQueryParamPool)
+		}
+	}
+
+	if pool := query.Get(cacheDSNQueryParamPool); pool != "" {
+		if dsn.PoolSize, err = strconv.Atoi(pool); err != nil || dsn.PoolSize <= 0 {
+			return nil, fmt.Errorf("the dsn '%s' has the '%s' option with a value of '%s' but it must be a positive integer", redacted, cacheDSNQueryParamPool, pool)
+		}
+	}
+
+	return dsn, nil
+}
+
+// CacheDSN represents a cache backend decoded from a DSN. The String method returns the DSN with the password redacted.
+type CacheDSN struct {
+	Backend  string
+	Address  *AddressTCP
+	Username string
+	Password string
+	Database int
+	PoolSize int
+}
+
+// String returns the DSN with the password redacted.
+func (d CacheDSN) String() string {
+	return d.URL().Redacted()
+}
+
+// URL returns the DSN as a *url.URL including the password.
+func (d CacheDSN) URL() *url.URL {
+	u := &url.URL{Scheme: d.Backend}
+
+	if d.Address != nil {
+		u.Host = d.Address.NetworkAddress()
+		u.Path = "/" + strconv.Itoa(d.Database)
+	}
+
+	switch {
+	case d.Password != "":
+		u.User = url.UserPassword(d.Username, d.Password)
+	case d.Username != "":
+		u.User = url.User(d.Username)
+	}
+
+	if d.PoolSize != 0 {
+		u.RawQuery = url.Values{cacheDSNQueryParamPool: []string{strconv.Itoa(d.PoolSize)}}.Encode()
+	}
+
+	return u
+}
+
+// ByteSize represents a size in bytes which is typically decoded from a human-readable string such as '4MB'.
+type ByteSize int64
+
+// NewURLWithIDN returns a *URLWithIDN given a *url.URL. If the hostname is an internationalized domain name it's
+// converted to the ASCII punycode form.
+func NewURLWithIDN(u *url.URL) (*URLWithIDN, error) {
+	if u == nil {
+		return nil, fmt.Errorf("url is nil")
+	}
+
+	result := &URLWithIDN{URL: *u}
+
+	hostname := u.Hostname()
+
+	if !isASCII(hostname) {
+		ascii, err := idna.Lookup.ToASCII(hostname)
+		if err != nil {
+			return nil, fmt.Errorf("could not convert the hostname '%s' to punycode: %w", hostname, err)
+		}
+
+		if port := u.Port(); port != "" {
+			result.Host = net.JoinHostPort(ascii, port)
+		} else {
+			result.Host = ascii
+		}
+	}
+
+	return result, nil
+}
+
+// URLWithIDN is a url.URL which always has a host in the ASCII punycode form suitable for dialing. The Unicode form of
+// the host suitable for display is available via the DisplayHostname and DisplayHost methods.
+type URLWithIDN struct {
+	url.URL
+}
+
+// DisplayHostname returns the hostname in the Unicode form. If the hostname can't be converted the ASCII form is
+// returned.
+func (u URLWithIDN) DisplayHostname() string {
+	hostname := u.Hostname()
+
+	if display, err := idna.Display.ToUnicode(hostname); err == nil {
+		return display
+	}
+
+	return hostname
+}
+
+// DisplayHost returns the host in the Unicode form including the port if present.
+func (u URLWithIDN) DisplayHost() string {
+	if port := u.Port(); port != "" {
+		return net.JoinHostPort(u.DisplayHostname(), port)
+	}
+
+	return u.DisplayHostname()
+}
+
+func isASCII(value string) bool {
+	for i := 0; i < len(value); i++ {
+		if value[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+
+	return true
+}
+
+// RedirectTarget represents a URL which is the target of a redirect and the MaxDepth of the redirect chain which is
+// permitted when it's followed. The MaxDepth is -1 if the URL didn't specify a maximum depth.
+type RedirectTarget struct {
+	URL      *url.URL
+	MaxDepth int
+}
+
+// URLWithUserInfo represents a URL which may have user info such as credentials. The user info is removed from the URL
+// and kept in the UserInfo so the URL can be safely logged. The UserInfo is nil if the URL didn't have user info.
+type URLWithUserInfo struct {
+	URL      *url.URL
+	UserInfo *url.Userinfo
+}
+
+// Unredacted returns a copy of the URL with the UserInfo restored.
+func (u URLWithUserInfo) Unredacted() *url.URL {
+	if u.URL == nil {
+		return nil
+	}
+
+	result := *u.URL
+	result.User = u.UserInfo
+
+	return &result
+}
+
+// String returns the URL without the UserInfo.
+func (u URLWithUserInfo) String() string {
+	if u.URL == nil {
+		return ""
+	}
+
+	return u.URL.String()
+}
+
+// RewriteRule represents a rule which rewrites values matching the Pattern using the Replacement. The Replacement may
+// reference the capture groups of the Pattern using the regexp.Regexp Expand syntax.
+type RewriteRule struct {
+	Pattern     *regexp.Regexp
+	Replacement string
+}
+
+// IPNetworkRule represents a Network which is either included or, when Negate is true, excluded from a list of
+// networks. The order of the rules is significant and is evaluated by the consumer. The Zone is the optional IPv6 zone
+// identifier of the Network such as the 'eth0' of 'fe80::1%eth0/64'.
+type IPNetworkRule struct {
+	Network *net.IPNet
+	Zone    string
+	Negate  bool
+}
+
+// String returns the string representation of the IPNetworkRule including the negation prefix and zone identifier.
+func (r IPNetworkRule) String() string {
+	var prefix string
+
+	if r.Negate {
+		prefix = "!"
+	}
+
+	if r.Network == nil {
+		return prefix + "<nil>"
+	}
+
+	if r.Zone == "" {
+		return prefix + r.Network.String()
+	}
+
+	ones, _ := r.Network.Mask.Size()
+
+	return fmt.Sprintf("%s%s%%%s/%d", prefix, r.Network.IP, r.Zone, ones)
+}
+
+// LDAPAttr represents an LDAP attribute Name and the optional Alias it's referred to by.
+type LDAPAttr struct {
+	Name  string
+	Alias string
+}
+
+// ReputationFeed represents the URL of an IP reputation feed and the Refresh interval at which it's fetched.
+type ReputationFeed struct {
+	URL     *url.URL
+	Refresh time.Duration
+}
+
+// GeoIPConfig represents a reference to a GeoIP database and the Provider which produced it.
+type GeoIPConfig struct {
+	Provider string `koanf:"provider" yaml:"provider" toml:"provider" json:"provider" jsonschema:"enum=maxmind,enum=dbip,enum=ip2location,title=Provider" jsonschema_description:"The provider of the GeoIP database."`
+	Path     string `koanf:"path" yaml:"path" toml:"path" json:"path" jsonschema:"title=Path" jsonschema_description:"The path to the GeoIP database."`
+}
+
+// BackupConfig represents an automated backup which is performed on the cron Schedule, written to the Path, and of
+// which the most recent Retain backups are kept.
+type BackupConfig struct {
+	Schedule string `koanf:"schedule" yaml:"schedule" toml:"schedule" json:"schedule" jsonschema:"title=Schedule" jsonschema_description:"The cron schedule the backup is performed on."`
+	Retain   int    `koanf:"retain" yaml:"retain" toml:"retain" json:"retain" jsonschema:"minimum=1,title=Retain" jsonschema_description:"The number of backups to retain."`
+	Path     string `koanf:"path" yaml:"path" toml:"path" json:"path" jsonschema:"title=Path" jsonschema_description:"The path the backups are written to."`
+}
+
+// CronScheduleWildcard is the bit which is set on the DaysOfMonth or DaysOfWeek of a CronSchedule when the field is
+// the '*' wildcard without a step.
+const CronScheduleWildcard uint64 = 1 << 63
+
+// CronSchedule represents a parsed cron Expression. Each field is the set of values which match the field, where the
+// bit at the index of the value is set if the value matches. Sunday is always the value 0 of the DaysOfWeek. As with
+// standard cron, a time matches the days if both DaysOfMonth and DaysOfWeek match, or either matches when neither is
+// the CronScheduleWildcard.
+type CronSchedule struct {
+	Expression  string
+	Seconds     uint64
+	Minutes     uint64
+	Hours       uint64
+	DaysOfMonth uint64
+	Months      uint64
+	DaysOfWeek  uint64
+}
+
+// String returns the Expression.
+func (s CronSchedule) String() string {
+	return s.Expression
+}
+
+// Next returns the earliest time after t which matches the schedule in the location of t, or the zero time.Time if
+// there is no matching time within five years.
+func (s CronSchedule) Next(t time.Time) time.Time {
+	if s.Seconds == 0 || s.Minutes == 0 || s.Hours == 0 || s.DaysOfMonth == 0 || s.Months == 0 || s.DaysOfWeek == 0 {
+		return time.Time{}
+	}
+
+	loc := t.Location()
+
+	t = t.Truncate(time.Second).Add(time.Second)
+
+	limit := t.Year() + 5
+
+	for t.Year() <= limit {
+		switch {
+		case s.Months&(1<<uint(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+		case !s.matchesDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+		case s.Hours&(1<<uint(t.Hour())) == 0:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+		case s.Minutes&(1<<uint(t.Minute())) == 0:
+			t = t.Truncate(time.Minute).Add(time.Minute)
+		case s.Seconds&(1<<uint(t.Second())) == 0:
+			t = t.Add(time.Second)
+		default:
+			return t
+		}
+	}
+
+	return time.Time{}
+}
+
+func (s CronSchedule) matchesDay(t time.Time) bool {
+	dom := s.DaysOfMonth&(1<<uint(t.Day())) != 0
+	dow := s.DaysOfWeek&(1<<uint(t.Weekday())) != 0
+
+	if s.DaysOfMonth&CronScheduleWildcard != 0 || s.DaysOfWeek&CronScheduleWildcard != 0 {
+		return dom && dow
+	}
+
+	return dom || dow
+}
+
+// DeviceFlowConfig represents the OAuth 2.0 Device Authorization Grant VerificationURI which users visit to enter the
+// user code, the UserCodeLength of the user code, and the minimum PollingInterval between token requests.
+type DeviceFlowConfig struct {
+	VerificationURI *url.URL      `koanf:"uri" yaml:"uri" toml:"uri" json:"uri" jsonschema:"title=Verification URI" jsonschema_description:"The verification URI which users visit to enter the user code."`
+	UserCodeLength  int           `koanf:"code_len" yaml:"code_len" toml:"code_len" json:"code_len" jsonschema:"minimum=6,maximum=20,title=User Code Length" jsonschema_description:"The length of the user code."`
+	PollingInterval time.Duration `koanf:"interval" yaml:"interval" toml:"interval" json:"interval" jsonschema:"title=Polling Interval" jsonschema_description:"The minimum interval between token requests."`
+}
+
+// WebhookDelivery represents the Timeout of a single webhook delivery attempt, the number of Retries after a failed
+// attempt, and the Backoff between attempts.
+type WebhookDelivery struct {
+	Timeout time.Duration `koanf:"timeout" yaml:"timeout" toml:"timeout" json:"timeout" jsonschema:"title=Timeout" jsonschema_description:"The timeout of a single delivery attempt."`
+	Retries int           `koanf:"retries" yaml:"retries" toml:"retries" json:"retries" jsonschema:"minimum=0,title=Retries" jsonschema_description:"The number of retries after a failed delivery attempt."`
+	Backoff time.Duration `koanf:"backoff" yaml:"backoff" toml:"backoff" json:"backoff" jsonschema:"title=Backoff" jsonschema_description:"The duration to wait between delivery attempts."`
+}
+
+// SessionCrypto represents the Algorithm and Key used to encrypt the session storage, and the Rotate interval after
+// which the key is rotated. A Rotate interval of 0 disables the rotation.
+type SessionCrypto struct {
+	Algorithm string        `koanf:"algo" yaml:"algo" toml:"algo" json:"algo" jsonschema:"enum=aes-gcm,enum=chacha20-poly1305,enum=xchacha20-poly1305,title=Algorithm" jsonschema_description:"The algorithm used to encrypt the session storage."`
+	Key       []byte        `koanf:"key" yaml:"key" toml:"key" json:"key" jsonschema:"title=Key" jsonschema_description:"The key used to encrypt the session storage."`
+	Rotate    time.Duration `koanf:"rotate" yaml:"rotate" toml:"rotate" json:"rotate" jsonschema:"title=Rotate" jsonschema_description:"The interval after which the key is rotated."`
+}
+
+// AuditSink represents a destination the audit log is written to. It's typically decoded from the compact inline
+// string form.
+type AuditSink struct {
+	Type   string   `koanf:"type" yaml:"type" toml:"type" json:"type" jsonschema:"enum=file,enum=syslog,title=Type" jsonschema_description:"The type of audit log sink."`
+	Path   string   `koanf:"path" yaml:"path,omitempty" toml:"path,omitempty" json:"path,omitempty" jsonschema:"title=Path" jsonschema_description:"The absolute path of the audit log for the file sink type."`
+	Format string   `koanf:"format" yaml:"format,omitempty" toml:"format,omitempty" json:"format,omitempty" jsonschema:"enum=text,enum=json,title=Format" jsonschema_description:"The format of the audit log entries."`
+	Rotate ByteSize `koanf:"rotate" yaml:"rotate,omitempty" toml:"rotate,omitempty" json:"rotate,omitempty" jsonschema:"title=Rotate" jsonschema_description:"The size after which the audit log is rotated for the file sink type."`
+}
+
+// HealthCheck represents the Path of an upstream which is requested every Interval to check the health of the upstream,
+// the Timeout of each check, and the number of consecutive checks which must succeed or fail for the upstream to be
+// considered Healthy or Unhealthy respectively.
+type HealthCheck struct {
+	Path      string        `koanf:"path" yaml:"path" toml:"path" json:"path" jsonschema:"title=Path" jsonschema_description:"The path of the upstream which is requested to check the health of the upstream."`
+	Interval  time.Duration `koanf:"interval" yaml:"interval" toml:"interval" json:"interval" jsonschema:"title=Interval" jsonschema_description:"The interval between health checks."`
+	Timeout   time.Duration `koanf:"timeout" yaml:"timeout" toml:"timeout" json:"timeout" jsonschema:"title=Timeout" jsonschema_description:"The timeout of each health check."`
+	Healthy   int           `koanf:"healthy" yaml:"healthy" toml:"healthy" json:"healthy" jsonschema:"minimum=1,title=Healthy" jsonschema_description:"The number of consecutive successful health checks before the upstream is considered healthy."`
+	Unhealthy int           `koanf:"unhealthy" yaml:"unhealthy" toml:"unhealthy" json:"unhealthy" jsonschema:"minimum=1,title=Unhealthy" jsonschema_description:"The number of consecutive failed health checks before the upstream is considered unhealthy."`
+}
+
+// Color represents an sRGB color with the R, G, B, and A components. It implements the color.Color interface.
+type Color struct {
+	R, G, B, A uint8
+}
+
+// RGBA returns the alpha-premultiplied red, green, blue, and alpha values of the color.
+func (c Color) RGBA() (r, g, b, a uint32) {
+	return color.NRGBA{R: c.R, G: c.G, B: c.B, A: c.A}.RGBA()
+}
+
+// String returns the canonical lowercase hex form of the color in the format '#rrggbb'.
+func (c Color) String() string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+// WeightedLocale represents a locale Tag and the relative Q weight (quality value) it's preferred with.
+type WeightedLocale struct {
+	Tag language.Tag
+	Q   float64
+}
+
+// AudienceKey represents a JSON Web Key with the key id KID which is restricted to the Audience.
+type AudienceKey struct {
+	KID      string
+	Audience string
+}
+
+// Fingerprint represents the SHA-256 fingerprint of a certificate which is trusted.
+type Fingerprint []byte
+
+// OIDCScopeClaims is a map of OpenID Connect 1.0 scope names to the claims which are released when the scope is granted.
+type OIDCScopeClaims map[string][]string
+
+// ConsentScopes is a map of consent categories such as 'required' and 'preauthorized' to the OpenID Connect 1.0 scopes
+// in that category.
+type ConsentScopes map[string][]string
+
+// DefaultClaims is a map of OpenID Connect 1.0 claim names to the static values which are released by default. The
+// values are either a string, bool, int64, or float64.
+type DefaultClaims map[string]any
+
+// EnvironmentURLs is a map of environment names to the *url.URL which applies when the environment is active.
+type EnvironmentURLs map[string]*url.URL
+
+// RateLimit represents a limit of Requests which are permitted within a Period.
+type RateLimit struct {
+	Requests int
+	Period   time.Duration
+}
+
+// RateLimitTiers is a map of rate limit subject types to the RateLimit which applies to that subject type.
+type RateLimitTiers map[string]RateLimit
+
+// EndpointRateLimits is a map of endpoint path patterns to the RateLimit which applies to that endpoint.
+type EndpointRateLimits map[string]RateLimit
+
+// DurationSchedule is a map of schedule window labels to the time.Duration which applies during that window.
+type DurationSchedule map[string]time.Duration
+
+// JWTAlgorithm represents a JSON Web Algorithm (JWA) used to sign a JSON Web Token.
+type JWTAlgorithm string
+
+// NewAnchoredTime returns an AnchoredTime given the anchor time and the offset from the anchor.
+func NewAnchoredTime(anchor time.Time, offset time.Duration) AnchoredTime {
+	return AnchoredTime{anchor: anchor, offset: offset}
+}
+
+// AnchoredTime is an absolute time.Time which was computed from a time.Duration offset relative to an anchor such as
+// the time the configuration was loaded.
+type AnchoredTime struct {
+	anchor time.Time
+	offset time.Duration
+}
+
+// Time returns the absolute time.Time.
+func (t AnchoredTime) Time() time.Time {
+	return t.anchor.Add(t.offset)
+}
+
+// Anchor returns the anchor time.Time the offset is relative to.
+func (t AnchoredTime) Anchor() time.Time {
+	return t.anchor
+}
+
+// Offset returns the time.Duration offset relative to the anchor.
+func (t AnchoredTime) Offset() time.Duration {
+	return t.offset
+}
+
+// IsZero returns true if the AnchoredTime has not been set.
+func (t AnchoredTime) IsZero() bool {
+	return t.anchor.IsZero() && t.offset == 0
+}
+
+// JSONSchema provides the json-schema formatting.
+func (AnchoredTime) JSONSchema() *jsonschema.Schema {
+	return &jsonschema.Schema{
+		Type:    jsonschema.TypeString,
+		Pattern: `^now\s*([+-]\s*\d+.*)?$`,
+	}
+}
+
 type IdentityProvidersOpenIDConnectClientURIs []string
 
 func (IdentityProvidersOpenIDConnectClientURIs) JSONSchema() *jsonschema.Schema {
@@ -2,12 +2,17 @@ package schema
 
 import (
 	"fmt"
+	"math"
 	"net"
 	"net/url"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/authelia/jsonschema"
+
+	"github.com/authelia/authelia/v4/internal/utils"
 )
 
 // NewAddress returns an *Address and error depending on the ability to parse the string as an Address.
@@ -19,29 +24,94 @@ func NewAddress(value string) (address *Address, err error) {
 
 // NewAddressDefault returns an *Address and error depending on the ability to parse the string as an Address.
 // It also assumes any value without a scheme which looks like a path is the schemeDefaultPath scheme, and everything
-// else without a scheme is the schemeDefault scheme.
+// else without a scheme is the schemeDefault scheme. Addresses with the TCP and UDP schemes may have a port range in
+// the format of '<start>-<end>' instead of a single port.
 func NewAddressDefault(value, schemeDefault, schemeDefaultPath string) (address *Address, err error) {
 	if len(value) == 0 {
-		return &Address{true, false, -1, 0, nil, &url.URL{Scheme: AddressSchemeTCP, Host: ":0"}}, nil
+		return &Address{true, false, -1, 0, 0, nil, 0, &url.URL{Scheme: AddressSchemeTCP, Host: ":0"}}, nil
 	}
 
-	var u *url.URL
+	var (
+		u       *url.URL
+		raw     string
+		portEnd uint16
+	)
 
-	if regexpHasScheme.MatchString(value) {
-		u, err = url.Parse(value)
-	} else {
-		if strings.HasPrefix(value, "/") {
-			u, err = url.Parse(fmt.Sprintf("%s://%s", schemeDefaultPath, value))
-		} else {
-			u, err = url.Parse(fmt.Sprintf("%s://%s", schemeDefault, value))
-		}
+	switch {
+	case regexpHasScheme.MatchString(value):
+		raw = addressEscapeUserInfo(value)
+	case strings.HasPrefix(value, "/"):
+		raw = fmt.Sprintf("%s://%s", schemeDefaultPath, value)
+	default:
+		raw = addressEscapeUserInfo(fmt.Sprintf("%s://%s", schemeDefault, value))
 	}
 
-	if err != nil {
+	if raw, portEnd, err = addressSplitPortRange(raw); err != nil {
+		return nil, fmt.Errorf("could not parse string '%s' as address: %w", value, err)
+	}
+
+	if u, err = url.Parse(raw); err != nil {
 		return nil, fmt.Errorf("could not parse string '%s' as address: expected format is [<scheme>://]<hostname>[:<port>]: %w", value, err)
 	}
 
-	return NewAddressFromURL(u)
+	if address, err = NewAddressFromURL(u); err != nil {
+		return nil, err
+	}
+
+	if portEnd != 0 {
+		if !address.IsTCP() && !address.IsUDP() {
+			return nil, fmt.Errorf("could not parse string '%s' as address: the address has a port range but port ranges are only valid for the 'tcp', 'tcp4', 'tcp6', 'udp', 'udp4', and 'udp6' schemes", value)
+		}
+
+		address.portEnd = portEnd
+	}
+
+	return address, nil
+}
+
+// addressSplitPortRange removes the end of a port range from the authority of the raw url string, returning the raw url
+// string with just the start port, and the end port or 0 if the authority doesn't have a port range or the start and
+// end port are the same.
+func addressSplitPortRange(raw string) (result string, portEnd uint16, err error) {
+	i := strings.Index(raw, "://")
+	if i == -1 {
+		return raw, 0, nil
+	}
+
+	i += len("://")
+
+	authority := raw[i:]
+
+	if j := strings.IndexAny(authority, "/?#"); j != -1 {
+		authority = authority[:j]
+	}
+
+	matches := regexpAddressPortRange.FindStringSubmatch(authority)
+	if matches == nil {
+		return raw, 0, nil
+	}
+
+	var start, end uint64
+
+	if start, err = strconv.ParseUint(matches[2], 10, 16); err != nil {
+		return "", 0, fmt.Errorf("the port range '%s-%s' is invalid as the start port exceeds the maximum port %d", matches[2], matches[3], math.MaxUint16)
+	}
+
+	if end, err = strconv.ParseUint(matches[3], 10, 16); err != nil {
+		return "", 0, fmt.Errorf("the port range '%s-%s' is invalid as the end port exceeds the maximum port %d", matches[2], matches[3], math.MaxUint16)
+	}
+
+	if start > end {
+		return "", 0, fmt.Errorf("the port range '%s-%s' is invalid as the start port is greater than the end port", matches[2], matches[3])
+	}
+
+	result = raw[:i] + matches[1] + ":" + matches[2] + raw[i+len(authority):]
+
+	if start == end {
+		return result, 0, nil
+	}
+
+	return result, uint16(end), nil
 }
 
 // NewAddressFromNetworkValuesDefault returns an *Address and error depending on the ability to parse the string as an Address.
@@ -51,15 +121,15 @@ func NewAddressFromNetworkValuesDefault(value string, port uint16, schemeDefault
 	var u *url.URL
 
 	if regexpHasScheme.MatchString(value) {
-		u, err = url.Parse(value)
+		u, err = url.Parse(addressEscapeUserInfo(value))
 	} else {
 		switch {
 		case strings.HasPrefix(value, "/"):
 			u, err = url.Parse(fmt.Sprintf("%s://%s", schemeDefaultPath, value))
 		case port > 0:
-			u, err = url.Parse(fmt.Sprintf("%s://%s:%d", schemeDefault, value, port))
+			u, err = url.Parse(addressEscapeUserInfo(fmt.Sprintf("%s://%s:%d", schemeDefault, value, port)))
 		default:
-			u, err = url.Parse(fmt.Sprintf("%s://%s", schemeDefault, value))
+			u, err = url.Parse(addressEscapeUserInfo(fmt.Sprintf("%s://%s", schemeDefault, value)))
 		}
 	}
 
@@ -70,9 +140,56 @@ func NewAddressFromNetworkValuesDefault(value string, port uint16, schemeDefault
 	return NewAddressFromURL(u)
 }
 
+// addressEscapeUserInfo escapes each '%' character in the user info of a raw address which is not the start of a valid
+// percent-encoded sequence. User info is not valid for addresses, and this ensures literal '%' characters such as those
+// which may appear in passwords result in the user info validation error which redacts the password rather than a
+// parsing error which includes it.
+func addressEscapeUserInfo(value string) string {
+	i := strings.Index(value, "://")
+	if i == -1 {
+		return value
+	}
+
+	authority := value[i+3:]
+
+	if j := strings.IndexAny(authority, "/?#"); j != -1 {
+		authority = authority[:j]
+	}
+
+	n := strings.LastIndex(authority, "@")
+	if n == -1 || !strings.Contains(authority[:n], "%") {
+		return value
+	}
+
+	userinfo := authority[:n]
+
+	var b strings.Builder
+
+	for k := 0; k < len(userinfo); k++ {
+		if userinfo[k] == '%' && (k+2 >= len(userinfo) || !isHex(userinfo[k+1]) || !isHex(userinfo[k+2])) {
+			b.WriteString("%25")
+
+			continue
+		}
+
+		b.WriteByte(userinfo[k])
+	}
+
+	return value[:i+3] + b.String() + value[i+3+n:]
+}
+
+func isHex(c byte) bool {
+	switch {
+	case '0' <= c && c <= '9', 'a' <= c && c <= 'f', 'A' <= c && c <= 'F':
+		return true
+	default:
+		return false
+	}
+}
+
 // NewAddressUnix returns an *Address from a path value.
 func NewAddressUnix(path string) Address {
-	return Address{true, true, -1, 0, nil, &url.URL{Scheme: AddressSchemeUnix, Path: path}}
+	return Address{true, true, -1, 0, 0, nil, 0, &url.URL{Scheme: AddressSchemeUnix, Path: path}}
 }
 
 // NewAddressFromNetworkValues returns an *Address from network values.
@@ -82,7 +199,7 @@ func NewAddressFromNetworkValues(network, host string, port uint16) Address {
 
 // NewAddressFromNetworkPathValues returns an *Address from network values and a path.
 func NewAddressFromNetworkPathValues(network, host string, port uint16, path string) Address {
-	return Address{true, false, -1, port, nil, &url.URL{Scheme: network, Host: fmt.Sprintf("%s:%d", host, port), Path: path}}
+	return Address{true, false, -1, port, 0, nil, 0, &url.URL{Scheme: network, Host: fmt.Sprintf("%s:%d", host, port), Path: path}}
 }
 
 // NewSMTPAddress returns an *AddressSMTP from SMTP values.
@@ -109,11 +226,21 @@ func NewSMTPAddress(scheme, host string, port uint16) *AddressSMTP {
 		}
 	}
 
-	return &AddressSMTP{Address: Address{true, false, -1, port, nil, &url.URL{Scheme: scheme, Host: fmt.Sprintf("%s:%d", host, port)}}}
+	return &AddressSMTP{Address: Address{true, false, -1, port, 0, nil, 0, &url.URL{Scheme: scheme, Host: fmt.Sprintf("%s:%d", host, port)}}}
 }
 
-// NewAddressFromURL returns an *Address and error depending on the ability to parse the *url.URL as an Address.
+// NewAddressFromURL returns an *Address and error depending on the ability to parse the *url.URL as an Address. The
+// scheme is lowercased so that values like 'LDAPS' or 'Tcp' match the same specializations as their lowercase forms,
+// and the *url.URL is copied when this changes the scheme so the callers value is not modified.
 func NewAddressFromURL(u *url.URL) (addr *Address, err error) {
+	if u != nil {
+		if scheme := strings.ToLower(u.Scheme); scheme != u.Scheme {
+			normalized := *u
+			normalized.Scheme = scheme
+			u = &normalized
+		}
+	}
+
 	addr = &Address{
 		url:   u,
 		umask: -1,
@@ -154,6 +281,20 @@ func (AddressUDP) JSONSchema() *jsonschema.Schema {
 	}
 }
 
+// AddressQUIC is just a type with an underlying type of Address which is used for QUIC listeners such as HTTP/3.
+type AddressQUIC struct {
+	Address
+}
+
+// JSONSchema returns the appropriate *jsonschema.Schema for this type.
+func (AddressQUIC) JSONSchema() *jsonschema.Schema {
+	return &jsonschema.Schema{
+		Type:    jsonschema.TypeString,
+		Format:  "uri",
+		Pattern: `^(udp[46]?:\/\/)?([^:\/]*(:\d+)|[^:\/]+(:\d+)?)(\/.*)?$`,
+	}
+}
+
 // AddressLDAP is just a type with an underlying type of Address.
 type AddressLDAP struct {
 	Address
@@ -184,11 +325,14 @@ func (AddressSMTP) JSONSchema() *jsonschema.Schema {
 
 // Address represents an address.
 type Address struct {
-	valid  bool
-	socket bool
-	umask  int
-	port   uint16
-	fd     *uint64
+	valid   bool
+	socket  bool
+	umask   int
+	port    uint16
+	portEnd uint16
+	fd      *uint64
+
+	connMaxLifetime time.Duration
 
 	url *url.URL
 }
@@ -316,13 +460,159 @@ func (a *Address) ValidateLDAP() error {
 	}
 }
 
+// ValidateHostname returns an error if the hostname is not a syntactically valid IP address or DNS name, or if the
+// Address is a unix domain socket and the socket path is empty. An empty hostname is considered valid for non-socket
+// addresses as it represents all interfaces for listeners.
+func (a *Address) ValidateHostname() error {
+	if !a.valid || a.url == nil {
+		return fmt.Errorf("address url is nil")
+	}
+
+	switch {
+	case a.fd != nil:
+		return nil
+	case a.socket:
+		if a.url.Path == "" && a.url.Scheme != AddressSchemeLDAPI {
+			return fmt.Errorf("the unix socket path is empty")
+		}
+
+		return nil
+	}
+
+	hostname := a.url.Hostname()
+
+	switch {
+	case hostname == "", net.ParseIP(hostname) != nil:
+		return nil
+	case len(hostname) > 253 || !regexpIsHostname.MatchString(hostname):
+		return fmt.Errorf("the hostname '%s' is not a valid IP address or DNS name", hostname)
+	default:
+		return nil
+	}
+}
+
+// FastOpen returns true if TCP Fast Open is enabled via the 'tfo' option.
+func (a *Address) FastOpen() bool {
+	if !a.valid || a.url == nil {
+		return false
+	}
+
+	tfo, _ := strconv.ParseBool(a.url.Query().Get(addressQueryParamTFO))
+
+	return tfo
+}
+
+// NoDelay returns false if Nagle's algorithm should be enabled by disabling TCP_NODELAY via the 'nodelay' option,
+// otherwise it returns true.
+func (a *Address) NoDelay() bool {
+	if !a.valid || a.url == nil {
+		return true
+	}
+
+	nodelay, err := strconv.ParseBool(a.url.Query().Get(addressQueryParamNoDelay))
+	if err != nil {
+		return true
+	}
+
+	return nodelay
+}
+
+// LocalAddr returns the net.Addr from the 'local_addr' option which should be used as the local address when dialing,
+// or nil if it's not set.
+func (a *Address) LocalAddr() net.Addr {
+	if !a.valid || a.url == nil {
+		return nil
+	}
+
+	ip := net.ParseIP(a.url.Query().Get(addressQueryParamLocalAddr))
+	if ip == nil {
+		return nil
+	}
+
+	if a.IsUDP() {
+		return &net.UDPAddr{IP: ip}
+	}
+
+	return &net.TCPAddr{IP: ip}
+}
+
+// DualStack returns false if dual-stack dialing (RFC6555 Happy Eyeballs) is disabled via the 'dualstack' option or the
+// 'family' option restricts dialing to a single address family, otherwise it returns true.
+func (a *Address) DualStack() bool {
+	if !a.valid || a.url == nil {
+		return true
+	}
+
+	query := a.url.Query()
+
+	switch {
+	case query.Get(addressQueryParamDualStack) == addressDualStackOff:
+		return false
+	case query.Get(addressQueryParamFamily) == addressFamilyIPv4Only, query.Get(addressQueryParamFamily) == addressFamilyIPv6Only:
+		return false
+	default:
+		return true
+	}
+}
+
+// DialNetwork returns the Network() restricted to the address family from the 'family' option if one is set.
+func (a *Address) DialNetwork() string {
+	network := a.Network()
+
+	if !a.valid || a.url == nil || network != AddressSchemeTCP {
+		return network
+	}
+
+	switch a.url.Query().Get(addressQueryParamFamily) {
+	case addressFamilyIPv4Only:
+		return AddressSchemeTCP4
+	case addressFamilyIPv6Only:
+		return AddressSchemeTCP6
+	default:
+		return network
+	}
+}
+
+// SendBufferSize returns the size in bytes of the socket send buffer from the 'sndbuf' option, or 0 if it's not set.
+func (a *Address) SendBufferSize() int {
+	return a.bufferSize(addressQueryParamSndBuf)
+}
+
+// ReceiveBufferSize returns the size in bytes of the socket receive buffer from the 'rcvbuf' option, or 0 if it's not
+// set.
+func (a *Address) ReceiveBufferSize() int {
+	return a.bufferSize(addressQueryParamRcvBuf)
+}
+
+func (a *Address) bufferSize(key string) int {
+	if !a.valid || a.url == nil {
+		return 0
+	}
+
+	size, _ := ParseByteSize(a.url.Query().Get(key))
+
+	return int(size)
+}
+
 // String returns a string representation of the Address.
 func (a *Address) String() string {
 	if !a.valid || a.url == nil {
 		return ""
 	}
 
-	return a.url.String()
+	return a.rangeURL().String()
+}
+
+func (a *Address) rangeURL() *url.URL {
+	if a.portEnd == 0 {
+		return a.url
+	}
+
+	u := *a.url
+
+	u.Host = fmt.Sprintf("%s-%d", u.Host, a.portEnd)
+
+	return &u
 }
 
 // Scheme returns the *url.URL Scheme field.
@@ -365,20 +655,86 @@ func (a *Address) SetHostname(hostname string) {
 	}
 }
 
-// Port returns the port.
+// Port returns the port. If the Address has a port range this is the start of the range.
 func (a *Address) Port() uint16 {
 	return a.port
 }
 
-// SetPort sets the port preserving the hostname.
+// IsPortRange returns true if the Address has a port range rather than a single port.
+func (a *Address) IsPortRange() bool {
+	return a.portEnd != 0
+}
+
+// PortRange returns the inclusive start and end of the port range. If the Address has a single port then both the
+// start and end are that port.
+func (a *Address) PortRange() (start, end uint16) {
+	if a.portEnd == 0 {
+		return a.port, a.port
+	}
+
+	return a.port, a.portEnd
+}
+
+// SetPort sets the port preserving the hostname. This removes the port range if the Address has one.
 func (a *Address) SetPort(port uint16) {
 	if !a.valid || a.url == nil {
 		return
 	}
 
+	a.portEnd = 0
+
 	a.setport(port)
 }
 
+// Backlog returns the accept backlog configured for a listener via the 'backlog' query parameter, or 0 if the system
+// default should be used.
+func (a *Address) Backlog() int {
+	if !a.valid || a.url == nil {
+		return 0
+	}
+
+	backlog, _ := strconv.Atoi(a.url.Query().Get(addressQueryParamBacklog))
+
+	return backlog
+}
+
+// ReusePort returns true if the SO_REUSEPORT socket option should be set on the listener via the 'reuseport' option,
+// which allows multiple processes to listen on the same port.
+func (a *Address) ReusePort() bool {
+	if !a.valid || a.url == nil {
+		return false
+	}
+
+	reuse, _ := strconv.ParseBool(a.url.Query().Get(addressQueryParamReusePort))
+
+	return reuse
+}
+
+// DSCP returns the Differentiated Services Code Point configured via the 'dscp' option which should be used to tag the
+// traffic of the socket, or -1 if it's not set.
+func (a *Address) DSCP() int {
+	if !a.valid || a.url == nil || !a.url.Query().Has(addressQueryParamDSCP) {
+		return -1
+	}
+
+	dscp, err := strconv.Atoi(a.url.Query().Get(addressQueryParamDSCP))
+	if err != nil {
+		return -1
+	}
+
+	return dscp
+}
+
+// ConnMaxLifetime returns the maximum amount of time a pooled SQL or LDAP connection may be reused configured via the
+// 'conn_max_lifetime' option, or 0 if it's not set which indicates connections may be reused indefinitely.
+func (a *Address) ConnMaxLifetime() time.Duration {
+	if !a.valid || a.url == nil {
+		return 0
+	}
+
+	return a.connMaxLifetime
+}
+
 // Path returns the path.
 func (a *Address) Path() string {
 	if !a.valid || a.url == nil {
@@ -461,7 +817,56 @@ func (a *Address) Dial() (net.Conn, error) {
 		return nil, fmt.Errorf("address url is nil")
 	}
 
-	return net.Dial(a.Network(), a.NetworkAddress())
+	dialer := &net.Dialer{LocalAddr: a.LocalAddr(), Control: a.control(false)}
+
+	if !a.DualStack() {
+		dialer.FallbackDelay = -1
+	}
+
+	conn, err := dialer.Dial(a.DialNetwork(), a.NetworkAddress())
+	if err != nil {
+		return nil, err
+	}
+
+	if tcp, ok := conn.(*net.TCPConn); ok && !a.NoDelay() {
+		if err = tcp.SetNoDelay(false); err != nil {
+			_ = conn.Close()
+
+			return nil, fmt.Errorf("error setting the '%s' option: %w", addressQueryParamNoDelay, err)
+		}
+	}
+
+	return conn, nil
+}
+
+// listenerWithNoDelay wraps the listener so the 'nodelay' option is applied to accepted connections, as TCP_NODELAY is
+// always enabled on new TCP connections and is not inherited from the listener.
+func (a *Address) listenerWithNoDelay(ln net.Listener) net.Listener {
+	if a.NoDelay() {
+		return ln
+	}
+
+	return &addressNoDelayListener{Listener: ln}
+}
+
+// addressNoDelayListener is a net.Listener which disables TCP_NODELAY on accepted TCP connections.
+type addressNoDelayListener struct {
+	net.Listener
+}
+
+// Accept waits for and returns the next connection to the listener.
+func (l *addressNoDelayListener) Accept() (conn net.Conn, err error) {
+	if conn, err = l.Listener.Accept(); err != nil {
+		return nil, err
+	}
+
+	if tcp, ok := conn.(*net.TCPConn); ok {
+		// The error is ignored as returning it would stop the server from accepting connections, and the connection is
+		// still usable with TCP_NODELAY enabled.
+		_ = tcp.SetNoDelay(false)
+	}
+
+	return conn, nil
 }
 
 func (a *Address) setport(port uint16) {
@@ -475,13 +880,15 @@ func (a *Address) validate() (err error) {
 		return fmt.Errorf("error validating the address: address url was nil")
 	}
 
+	if err = a.validateQuery(); err != nil {
+		return err
+	}
+
 	switch {
-	case a.url.RawQuery != "" && a.url.Scheme != AddressSchemeUnix && a.url.Scheme != AddressSchemeFileDescriptor:
-		return fmt.Errorf("error validating the address: the url '%s' appears to have a query but this is not valid for addresses with the '%s' scheme", a.url.String(), a.url.Scheme)
 	case a.url.RawFragment != "", a.url.Fragment != "":
-		return fmt.Errorf("error validating the address: the url '%s' appears to have a fragment but this is not valid for addresses", a.url.String())
+		return fmt.Errorf("error validating the address: the url '%s' appears to have a fragment but this is not valid for addresses", a.url.Redacted())
 	case a.url.User != nil && (a.url.Scheme != AddressSchemeUnix || a.url.User.Username() != ""):
-		return fmt.Errorf("error validating the address: the url '%s' appears to have user info but this is not valid for addresses", a.url.String())
+		return fmt.Errorf("error validating the address: the url '%s' appears to have user info but this is not valid for addresses", a.url.Redacted())
 	}
 
 	switch a.url.Scheme {
@@ -518,6 +925,229 @@ func (a *Address) validate() (err error) {
 	return nil
 }
 
+func (a *Address) validateQuery() (err error) {
+	if a.url.RawQuery == "" {
+		return nil
+	}
+
+	var query url.Values
+
+	if query, err = url.ParseQuery(a.url.RawQuery); err != nil {
+		return fmt.Errorf("error validating the address: the url '%s' appears to have a query which could not be parsed: %w", a.url.Redacted(), err)
+	}
+
+	keys := make([]string, 0, len(query))
+
+	for key := range query {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if len(query[key]) > 1 {
+			return fmt.Errorf("error validating the address: the url '%s' has the '%s' option specified %d times but it may only be specified once", a.url.Redacted(), key, len(query[key]))
+		}
+
+		switch key {
+		case addressQueryParamBacklog:
+			if err = a.validateQueryBacklog(query.Get(key)); err != nil {
+				return err
+			}
+		case addressQueryParamSndBuf, addressQueryParamRcvBuf:
+			if err = a.validateQueryBufferSize(key, query.Get(key)); err != nil {
+				return err
+			}
+		case addressQueryParamTFO, addressQueryParamNoDelay:
+			if err = a.validateQueryTCPBool(key, query.Get(key)); err != nil {
+				return err
+			}
+		case addressQueryParamLocalAddr:
+			if err = a.validateQueryLocalAddr(query.Get(key)); err != nil {
+				return err
+			}
+		case addressQueryParamDualStack, addressQueryParamFamily:
+			if err = a.validateQueryDualStack(key, query.Get(key)); err != nil {
+				return err
+			}
+		case addressQueryParamReusePort:
+			if err = a.validateQueryReusePort(query.Get(key)); err != nil {
+				return err
+			}
+		case addressQueryParamDSCP:
+			if err = a.validateQueryDSCP(query.Get(key)); err != nil {
+				return err
+			}
+		case addressQueryParamConnMaxLifetime:
+			if err = a.validateQueryConnMaxLifetime(query.Get(key)); err != nil {
+				return err
+			}
+		default:
+			if a.url.Scheme != AddressSchemeUnix && a.url.Scheme != AddressSchemeFileDescriptor {
+				return fmt.Errorf("error validating the address: the url '%s' appears to have a query but this is not valid for addresses with the '%s' scheme", a.url.Redacted(), a.url.Scheme)
+			}
+		}
+	}
+
+	return a.validateQueryDualStackConflicts(query)
+}
+
+func (a *Address) validateQueryBufferSize(key, value string) (err error) {
+	var size uint64
+
+	if size, err = ParseByteSize(value); err != nil {
+		return fmt.Errorf("error validating the address: the url '%s' has the '%s' option with a value of '%s' but it could not be parsed: %w", a.url.Redacted(), key, value, err)
+	}
+
+	if size == 0 || size > math.MaxInt32 {
+		return fmt.Errorf("error validating the address: the url '%s' has the '%s' option with a value of '%s' but it must be a positive byte size no larger than %d bytes", a.url.Redacted(), key, value, math.MaxInt32)
+	}
+
+	return nil
+}
+
+func (a *Address) validateQueryTCPBool(key, value string) (err error) {
+	switch a.url.Scheme {
+	case AddressSchemeTCP, AddressSchemeTCP4, AddressSchemeTCP6, AddressSchemeLDAP, AddressSchemeLDAPS, AddressSchemeSMTP, AddressSchemeSUBMISSION, AddressSchemeSUBMISSIONS:
+		break
+	default:
+		return fmt.Errorf("error validating the address: the url '%s' has the '%s' option but this is only valid for TCP addresses and addresses with the '%s' scheme are not TCP addresses", a.url.Redacted(), key, a.url.Scheme)
+	}
+
+	if _, err = strconv.ParseBool(value); err != nil {
+		return fmt.Errorf("error validating the address: the url '%s' has the '%s' option with a value of '%s' but it must be a boolean", a.url.Redacted(), key, value)
+	}
+
+	return nil
+}
+
+func (a *Address) validateQueryLocalAddr(value string) (err error) {
+	switch a.url.Scheme {
+	case AddressSchemeTCP, AddressSchemeTCP4, AddressSchemeTCP6, AddressSchemeUDP, AddressSchemeUDP4, AddressSchemeUDP6, AddressSchemeLDAP, AddressSchemeLDAPS, AddressSchemeSMTP, AddressSchemeSUBMISSION, AddressSchemeSUBMISSIONS:
+		break
+	default:
+		return fmt.Errorf("error validating the address: the url '%s' has the '%s' option but this is only valid for dialed TCP or UDP addresses and addresses with the '%s' scheme are not dialed TCP or UDP addresses", a.url.Redacted(), addressQueryParamLocalAddr, a.url.Scheme)
+	}
+
+	ip := net.ParseIP(value)
+
+	switch {
+	case ip == nil:
+		return fmt.Errorf("error validating the address: the url '%s' has the '%s' option with a value of '%s' but it must be an IP address", a.url.Redacted(), addressQueryParamLocalAddr, value)
+	case (a.url.Scheme == AddressSchemeTCP4 || a.url.Scheme == AddressSchemeUDP4) && ip.To4() == nil:
+		return fmt.Errorf("error validating the address: the url '%s' has the '%s' option with a value of '%s' but it must be an IPv4 address for addresses with the '%s' scheme", a.url.Redacted(), addressQueryParamLocalAddr, value, a.url.Scheme)
+	case (a.url.Scheme == AddressSchemeTCP6 || a.url.Scheme == AddressSchemeUDP6) && ip.To4() != nil:
+		return fmt.Errorf("error validating the address: the url '%s' has the '%s' option with a value of '%s' but it must be an IPv6 address for addresses with the '%s' scheme", a.url.Redacted(), addressQueryParamLocalAddr, value, a.url.Scheme)
+	}
+
+	return nil
+}
+
+func (a *Address) validateQueryDualStack(key, value string) (err error) {
+	switch a.url.Scheme {
+	case AddressSchemeTCP, AddressSchemeTCP4, AddressSchemeTCP6, AddressSchemeLDAP, AddressSchemeLDAPS, AddressSchemeSMTP, AddressSchemeSUBMISSION, AddressSchemeSUBMISSIONS:
+		break
+	default:
+		return fmt.Errorf("error validating the address: the url '%s' has the '%s' option but this is only valid for dialed TCP addresses and addresses with the '%s' scheme are not dialed TCP addresses", a.url.Redacted(), key, a.url.Scheme)
+	}
+
+	switch key {
+	case addressQueryParamDualStack:
+		if value != addressDualStackOn && value != addressDualStackOff {
+			return fmt.Errorf("error validating the address: the url '%s' has the '%s' option with a value of '%s' but it must be one of '%s' or '%s'", a.url.Redacted(), key, value, addressDualStackOn, addressDualStackOff)
+		}
+	default:
+		if value != addressFamilyDual && value != addressFamilyIPv4Only && value != addressFamilyIPv6Only {
+			return fmt.Errorf("error validating the address: the url '%s' has the '%s' option with a value of '%s' but it must be one of '%s', '%s', or '%s'", a.url.Redacted(), key, value, addressFamilyDual, addressFamilyIPv4Only, addressFamilyIPv6Only)
+		}
+	}
+
+	return nil
+}
+
+func (a *Address) validateQueryDualStackConflicts(query url.Values) (err error) {
+	dualstack, family := query.Get(addressQueryParamDualStack), query.Get(addressQueryParamFamily)
+
+	switch {
+	case dualstack == addressDualStackOn && (family == addressFamilyIPv4Only || family == addressFamilyIPv6Only),
+		dualstack == addressDualStackOff && family == addressFamilyDual:
+		return fmt.Errorf("error validating the address: the url '%s' has the '%s' option with a value of '%s' and the '%s' option with a value of '%s' but these options conflict", a.url.Redacted(), addressQueryParamDualStack, dualstack, addressQueryParamFamily, family)
+	case (a.url.Scheme == AddressSchemeTCP4 && family == addressFamilyIPv6Only) || (a.url.Scheme == AddressSchemeTCP6 && family == addressFamilyIPv4Only):
+		return fmt.Errorf("error validating the address: the url '%s' has the '%s' option with a value of '%s' but this conflicts with the '%s' scheme", a.url.Redacted(), addressQueryParamFamily, family, a.url.Scheme)
+	}
+
+	return nil
+}
+
+func (a *Address) validateQueryBacklog(value string) (err error) {
+	switch a.url.Scheme {
+	case AddressSchemeTCP, AddressSchemeTCP4, AddressSchemeTCP6, AddressSchemeUnix, AddressSchemeFileDescriptor:
+		break
+	default:
+		return fmt.Errorf("error validating the address: the url '%s' has the '%s' option but this is only valid for listener addresses and addresses with the '%s' scheme are not listener addresses", a.url.Redacted(), addressQueryParamBacklog, a.url.Scheme)
+	}
+
+	var backlog int64
+
+	if backlog, err = strconv.ParseInt(value, 10, 32); err != nil || backlog <= 0 {
+		return fmt.Errorf("error validating the address: the url '%s' has the '%s' option with a value of '%s' but it must be a positive integer", a.url.Redacted(), addressQueryParamBacklog, value)
+	}
+
+	return nil
+}
+
+func (a *Address) validateQueryReusePort(value string) (err error) {
+	switch a.url.Scheme {
+	case AddressSchemeTCP, AddressSchemeTCP4, AddressSchemeTCP6, AddressSchemeUDP, AddressSchemeUDP4, AddressSchemeUDP6:
+		break
+	default:
+		return fmt.Errorf("error validating the address: the url '%s' has the '%s' option but this is only valid for TCP and UDP listener addresses and addresses with the '%s' scheme are not TCP or UDP listener addresses", a.url.Redacted(), addressQueryParamReusePort, a.url.Scheme)
+	}
+
+	if _, err = strconv.ParseBool(value); err != nil {
+		return fmt.Errorf("error validating the address: the url '%s' has the '%s' option with a value of '%s' but it must be a boolean", a.url.Redacted(), addressQueryParamReusePort, value)
+	}
+
+	return nil
+}
+
+func (a *Address) validateQueryDSCP(value string) (err error) {
+	switch a.url.Scheme {
+	case AddressSchemeTCP, AddressSchemeTCP4, AddressSchemeTCP6, AddressSchemeUDP, AddressSchemeUDP4, AddressSchemeUDP6, AddressSchemeLDAP, AddressSchemeLDAPS, AddressSchemeSMTP, AddressSchemeSUBMISSION, AddressSchemeSUBMISSIONS:
+		break
+	default:
+		return fmt.Errorf("error validating the address: the url '%s' has the '%s' option but this is only valid for TCP or UDP addresses and addresses with the '%s' scheme are not TCP or UDP addresses", a.url.Redacted(), addressQueryParamDSCP, a.url.Scheme)
+	}
+
+	var dscp int64
+
+	if dscp, err = strconv.ParseInt(value, 10, 8); err != nil || dscp < 0 || dscp > 63 {
+		return fmt.Errorf("error validating the address: the url '%s' has the '%s' option with a value of '%s' but it must be an integer between 0 and 63", a.url.Redacted(), addressQueryParamDSCP, value)
+	}
+
+	return nil
+}
+
+func (a *Address) validateQueryConnMaxLifetime(value string) (err error) {
+	switch a.url.Scheme {
+	case AddressSchemeTCP, AddressSchemeTCP4, AddressSchemeTCP6, AddressSchemeUnix, AddressSchemeLDAP, AddressSchemeLDAPS, AddressSchemeLDAPI:
+		break
+	default:
+		return fmt.Errorf("error validating the address: the url '%s' has the '%s' option but this is only valid for pooled SQL or LDAP addresses and addresses with the '%s' scheme are not pooled SQL or LDAP addresses", a.url.Redacted(), addressQueryParamConnMaxLifetime, a.url.Scheme)
+	}
+
+	// The sign of a duration string is discarded when it's parsed so it must be checked before parsing.
+	if strings.HasPrefix(strings.TrimSpace(value), "-") {
+		return fmt.Errorf("error validating the address: the url '%s' has the '%s' option with a value of '%s' but it must be a non-negative duration such as '30m' where '0' indicates no limit", a.url.Redacted(), addressQueryParamConnMaxLifetime, value)
+	}
+
+	if a.connMaxLifetime, err = utils.ParseDurationString(strings.TrimSpace(value)); err != nil {
+		return fmt.Errorf("error validating the address: the url '%s' has the '%s' option with a value of '%s' but it must be a non-negative duration such as '30m' where '0' indicates no limit: %w", a.url.Redacted(), addressQueryParamConnMaxLifetime, value, err)
+	}
+
+	return nil
+}
+
 func (a *Address) validateProtocol() (err error) {
 	port := a.url.Port()
 
@@ -572,18 +1202,23 @@ func (a *Address) validateTCPUDP() (err error) {
 func (a *Address) validateUnixSocket() (err error) {
 	umask := -1
 
+	// Unix sockets can't be directories so any trailing slashes are trimmed from the socket path.
+	if path := strings.TrimRight(a.url.Path, "/"); path != "" {
+		a.url.Path, a.url.RawPath = path, strings.TrimRight(a.url.RawPath, "/")
+	}
+
 	switch {
-	case a.url.Path == "" && a.url.Scheme != AddressSchemeLDAPI && a.url.User == nil:
-		return fmt.Errorf("error validating the unix socket address: could not determine path from '%s'", a.url.String())
+	case strings.TrimRight(a.url.Path, "/") == "" && a.url.Scheme != AddressSchemeLDAPI && a.url.User == nil:
+		return fmt.Errorf("error validating the unix socket address: the unix socket path could not be determined from '%s'", a.unixSocketInput())
 	case a.url.Hostname() != "" && (a.url.User == nil || a.url.User.Username() != ""):
-		return fmt.Errorf("error validating the unix socket address: the url '%s' appears to have a hostname but this is not valid for unix sockets: this may occur if you omit the leading forward slash from the socket path", a.url.String())
+		return fmt.Errorf("error validating the unix socket address: the url '%s' appears to have a hostname but this is not valid for unix sockets: this may occur if you omit the leading forward slash from the socket path", a.url.Redacted())
 	}
 
 	if a.url.Query().Has(addressQueryParamUmask) {
 		v := a.url.Query().Get(addressQueryParamUmask)
 
 		if !regexpIsUmask.MatchString(v) {
-			return fmt.Errorf("error validating the unix socket address: could not parse address '%s': the address has a umask value of '%s' which does not appear to be a valid octal string", a.url.String(), v)
+			return fmt.Errorf("error validating the unix socket address: could not parse address '%s': the address has a umask value of '%s' which does not appear to be a valid octal string", a.url.Redacted(), v)
 		}
 
 		var p int64
@@ -599,6 +1234,18 @@ func (a *Address) validateUnixSocket() (err error) {
 	return nil
 }
 
+// unixSocketInput returns the redacted form of the url as it's likely to have been written. The url omits the '//' when
+// the host and path are both empty so 'unix://' would otherwise be shown as 'unix:'.
+func (a *Address) unixSocketInput() string {
+	redacted := a.url.Redacted()
+
+	if rest, ok := strings.CutPrefix(redacted, a.url.Scheme+":"); ok && a.url.Host == "" && !strings.HasPrefix(rest, "//") {
+		return a.url.Scheme + "://" + rest
+	}
+
+	return redacted
+}
+
 func (a *Address) validateFD() (err error) {
 	var actualFD uint64
 
@@ -612,7 +1259,7 @@ func (a *Address) validateFD() (err error) {
 		v := a.url.Query().Get(addressQueryParamUmask)
 
 		if !regexpIsUmask.MatchString(v) {
-			return fmt.Errorf("error validating the file descriptor address: could not parse address '%s': the address has a umask value of '%s' which does not appear to be a valid octal string", a.url.String(), v)
+			return fmt.Errorf("error validating the file descriptor address: could not parse address '%s': the address has a umask value of '%s' which does not appear to be a valid octal string", a.url.Redacted(), v)
 		}
 
 		var p int64
@@ -3,6 +3,7 @@
 package schema
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"os"
@@ -26,15 +27,61 @@ func (a *Address) Listener() (ln net.Listener, err error) {
 				_ = fd.Close()
 			}()
 
-			return net.FileListener(fd)
+			if ln, err = net.FileListener(fd); err != nil {
+				return nil, err
+			}
+
+			return a.listenerWithControl(ln)
 		}
 	} else {
 		create = func() (ln net.Listener, err error) {
-			return net.Listen(a.Network(), a.NetworkAddress())
+			return a.listenConfig().Listen(context.Background(), a.Network(), a.NetworkAddress())
 		}
 	}
 
-	return a.listenerWithUmask(create)
+	if ln, err = a.listenerWithUmask(create); err != nil {
+		return nil, err
+	}
+
+	if ln, err = a.listenerWithBacklog(ln); err != nil {
+		return nil, err
+	}
+
+	return a.listenerWithNoDelay(ln), nil
+}
+
+// listenerWithBacklog applies the 'backlog' option to the listener by calling listen again on the underlying socket,
+// which only adjusts the accept queue length of a socket which is already listening.
+func (a *Address) listenerWithBacklog(ln net.Listener) (net.Listener, error) {
+	backlog := a.Backlog()
+	if backlog == 0 {
+		return ln, nil
+	}
+
+	var (
+		raw syscall.RawConn
+		err error
+	)
+
+	if raw, err = listenerRawConn(ln); err != nil {
+		_ = ln.Close()
+
+		return nil, err
+	}
+
+	if errControl := raw.Control(func(fd uintptr) {
+		err = syscall.Listen(int(fd), backlog)
+	}); errControl != nil {
+		err = errControl
+	}
+
+	if err != nil {
+		_ = ln.Close()
+
+		return nil, fmt.Errorf("error setting the '%s' option on the listener: %w", addressQueryParamBacklog, err)
+	}
+
+	return ln, nil
 }
 
 func (a *Address) listenerWithUmask(create func() (net.Listener, error)) (ln net.Listener, err error) {
@@ -50,3 +97,37 @@ func (a *Address) listenerWithUmask(create func() (net.Listener, error)) (ln net
 
 	return create()
 }
+
+// listenerWithControl sets the socket options from the query on a listener which was not created by this Address.
+func (a *Address) listenerWithControl(ln net.Listener) (net.Listener, error) {
+	control := a.control(true)
+	if control == nil {
+		return ln, nil
+	}
+
+	var (
+		raw syscall.RawConn
+		err error
+	)
+
+	if raw, err = listenerRawConn(ln); err == nil {
+		err = control(ln.Addr().Network(), ln.Addr().String(), raw)
+	}
+
+	if err != nil {
+		_ = ln.Close()
+
+		return nil, err
+	}
+
+	return ln, nil
+}
+
+func listenerRawConn(ln net.Listener) (syscall.RawConn, error) {
+	conn, ok := ln.(syscall.Conn)
+	if !ok {
+		return nil, fmt.Errorf("the socket options are not supported for listeners of type %T", ln)
+	}
+
+	return conn.SyscallConn()
+}
@@ -3,6 +3,7 @@
 package schema
 
 import (
+	"context"
 	"fmt"
 	"net"
 )
@@ -13,5 +14,13 @@ func (a *Address) Listener() (ln net.Listener, err error) {
 		return nil, fmt.Errorf("address url is nil")
 	}
 
-	return net.Listen(a.Network(), a.NetworkAddress())
+	if a.Backlog() != 0 {
+		return nil, fmt.Errorf("the '%s' option is not supported on this platform", addressQueryParamBacklog)
+	}
+
+	if ln, err = a.listenConfig().Listen(context.Background(), a.Network(), a.NetworkAddress()); err != nil {
+		return nil, err
+	}
+
+	return a.listenerWithNoDelay(ln), nil
 }
@@ -0,0 +1,40 @@
+//go:build !linux && !freebsd && !darwin && !netbsd
+
+package schema
+
+import (
+	"fmt"
+	"net"
+	"syscall"
+)
+
+// listenConfig returns the net.ListenConfig for the listener which returns an error if any of the socket options from
+// the query are set as they're not supported on this platform.
+func (a *Address) listenConfig() *net.ListenConfig {
+	return &net.ListenConfig{Control: a.control(true)}
+}
+
+// control returns a function which returns an error if any of the socket options from the query are set as they're
+// not supported on this platform, or nil if there are no socket options to set.
+func (a *Address) control(listener bool) func(network, address string, c syscall.RawConn) error {
+	var name string
+
+	switch {
+	case listener && a.ReusePort():
+		name = addressQueryParamReusePort
+	case a.SendBufferSize() != 0:
+		name = addressQueryParamSndBuf
+	case a.ReceiveBufferSize() != 0:
+		name = addressQueryParamRcvBuf
+	case a.FastOpen():
+		name = addressQueryParamTFO
+	case a.DSCP() != -1:
+		name = addressQueryParamDSCP
+	default:
+		return nil
+	}
+
+	return func(network, address string, c syscall.RawConn) (err error) {
+		return fmt.Errorf("the '%s' option is not supported on this platform", name)
+	}
+}
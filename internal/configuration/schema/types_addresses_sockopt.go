@@ -0,0 +1,93 @@
+//go:build linux || freebsd || darwin || netbsd
+
+package schema
+
+import (
+	"fmt"
+	"net"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+// listenConfig returns the net.ListenConfig for the listener which sets the socket options from the query.
+func (a *Address) listenConfig() *net.ListenConfig {
+	return &net.ListenConfig{Control: a.control(true)}
+}
+
+// control returns the function which sets the socket options from the query on a socket before it's bound, or nil if
+// there are no socket options to set. The listener argument indicates if the socket is used for a listener or dialer.
+func (a *Address) control(listener bool) func(network, address string, c syscall.RawConn) error {
+	options := a.socketOptions(listener)
+
+	if len(options) == 0 {
+		return nil
+	}
+
+	return func(network, address string, c syscall.RawConn) (err error) {
+		for _, option := range options {
+			if option.set == nil {
+				return fmt.Errorf("the '%s' option is not supported on this platform", option.name)
+			}
+		}
+
+		if errControl := c.Control(func(fd uintptr) {
+			for _, option := range options {
+				if err = option.set(int(fd), network); err != nil {
+					err = fmt.Errorf("error setting the '%s' option: %w", option.name, err)
+
+					return
+				}
+			}
+		}); errControl != nil {
+			return errControl
+		}
+
+		return err
+	}
+}
+
+// addressSocketOption is a socket option which is set on a socket, where a nil set function indicates the socket
+// option is not supported on this platform.
+type addressSocketOption struct {
+	name string
+	set  func(fd int, network string) error
+}
+
+func (a *Address) socketOptions(listener bool) (options []addressSocketOption) {
+	if listener && a.ReusePort() {
+		options = append(options, addressSocketOption{addressQueryParamReusePort, func(fd int, _ string) error {
+			return unix.SetsockoptInt(fd, unix.SOL_SOCKET, unix.SO_REUSEPORT, 1)
+		}})
+	}
+
+	if size := a.SendBufferSize(); size != 0 {
+		options = append(options, addressSocketOption{addressQueryParamSndBuf, func(fd int, _ string) error {
+			return unix.SetsockoptInt(fd, unix.SOL_SOCKET, unix.SO_SNDBUF, size)
+		}})
+	}
+
+	if size := a.ReceiveBufferSize(); size != 0 {
+		options = append(options, addressSocketOption{addressQueryParamRcvBuf, func(fd int, _ string) error {
+			return unix.SetsockoptInt(fd, unix.SOL_SOCKET, unix.SO_RCVBUF, size)
+		}})
+	}
+
+	if a.FastOpen() {
+		options = append(options, addressSocketOption{addressQueryParamTFO, a.fastOpenSocketOption(listener)})
+	}
+
+	if dscp := a.DSCP(); dscp != -1 {
+		options = append(options, addressSocketOption{addressQueryParamDSCP, func(fd int, network string) error {
+			// The DSCP occupies the upper 6 bits of the Type of Service (IPv4) and Traffic Class (IPv6) fields.
+			switch network {
+			case AddressSchemeTCP6, AddressSchemeUDP6:
+				return unix.SetsockoptInt(fd, unix.IPPROTO_IPV6, unix.IPV6_TCLASS, dscp<<2)
+			default:
+				return unix.SetsockoptInt(fd, unix.IPPROTO_IP, unix.IP_TOS, dscp<<2)
+			}
+		}})
+	}
+
+	return options
+}
@@ -0,0 +1,195 @@
+//go:build linux
+
+package schema
+
+import (
+	"fmt"
+	"net"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/unix"
+)
+
+func TestAddress_SocketOptionsListener(t *testing.T) {
+	address, err := NewAddress("tcp://127.0.0.1:0?sndbuf=64KiB&rcvbuf=32KiB")
+	require.NoError(t, err)
+
+	ln, err := address.Listener()
+	require.NoError(t, err)
+
+	defer ln.Close()
+
+	// The linux kernel doubles the buffer sizes to allow space for bookkeeping overhead.
+	assert.Equal(t, 131072, testGetsockoptInt(t, ln.(syscall.Conn), unix.SOL_SOCKET, unix.SO_SNDBUF))
+	assert.Equal(t, 65536, testGetsockoptInt(t, ln.(syscall.Conn), unix.SOL_SOCKET, unix.SO_RCVBUF))
+}
+
+func TestAddress_SocketOptionsDial(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	defer ln.Close()
+
+	address, err := NewAddress(fmt.Sprintf("tcp://%s?sndbuf=32KiB&rcvbuf=64KiB", ln.Addr().String()))
+	require.NoError(t, err)
+
+	conn, err := address.Dial()
+	require.NoError(t, err)
+
+	defer conn.Close()
+
+	assert.Equal(t, 65536, testGetsockoptInt(t, conn.(syscall.Conn), unix.SOL_SOCKET, unix.SO_SNDBUF))
+	assert.Equal(t, 131072, testGetsockoptInt(t, conn.(syscall.Conn), unix.SOL_SOCKET, unix.SO_RCVBUF))
+}
+
+func TestAddress_FastOpenListener(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     string
+		expected int
+	}{
+		{
+			"ShouldUseDefaultQueueLength",
+			"tcp://127.0.0.1:0?tfo=true",
+			addressFastOpenQueueLength,
+		},
+		{
+			"ShouldUseBacklogQueueLength",
+			"tcp://127.0.0.1:0?tfo=true&backlog=64",
+			64,
+		},
+		{
+			"ShouldNotEnable",
+			"tcp://127.0.0.1:0?tfo=false",
+			0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			address, err := NewAddress(tc.have)
+			require.NoError(t, err)
+
+			ln, err := address.Listener()
+			require.NoError(t, err)
+
+			defer ln.Close()
+
+			assert.Equal(t, tc.expected, testGetsockoptInt(t, ln.(syscall.Conn), unix.IPPROTO_TCP, unix.TCP_FASTOPEN))
+		})
+	}
+}
+
+func TestAddress_FastOpenDial(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	defer ln.Close()
+
+	address, err := NewAddress(fmt.Sprintf("tcp://%s?tfo=true", ln.Addr().String()))
+	require.NoError(t, err)
+
+	conn, err := address.Dial()
+	require.NoError(t, err)
+
+	defer conn.Close()
+
+	assert.Equal(t, 1, testGetsockoptInt(t, conn.(syscall.Conn), unix.IPPROTO_TCP, unix.TCP_FASTOPEN_CONNECT))
+}
+
+func TestAddress_NoDelayListenerAndDial(t *testing.T) {
+	testCases := []struct {
+		name     string
+		query    string
+		expected int
+	}{
+		{
+			"ShouldDisableNoDelay",
+			"?nodelay=false",
+			0,
+		},
+		{
+			"ShouldEnableNoDelay",
+			"?nodelay=true",
+			1,
+		},
+		{
+			"ShouldEnableNoDelayByDefault",
+			"",
+			1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			listener, err := NewAddress("tcp://127.0.0.1:0" + tc.query)
+			require.NoError(t, err)
+
+			ln, err := listener.Listener()
+			require.NoError(t, err)
+
+			defer ln.Close()
+
+			dialer, err := NewAddress(fmt.Sprintf("tcp://%s%s", ln.Addr().String(), tc.query))
+			require.NoError(t, err)
+
+			conn, err := dialer.Dial()
+			require.NoError(t, err)
+
+			defer conn.Close()
+
+			accepted, err := ln.Accept()
+			require.NoError(t, err)
+
+			defer accepted.Close()
+
+			assert.Equal(t, tc.expected, testGetsockoptInt(t, conn.(syscall.Conn), unix.IPPROTO_TCP, unix.TCP_NODELAY))
+			assert.Equal(t, tc.expected, testGetsockoptInt(t, accepted.(syscall.Conn), unix.IPPROTO_TCP, unix.TCP_NODELAY))
+		})
+	}
+}
+
+func TestAddress_DSCPListenerAndDial(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	defer ln.Close()
+
+	dialer, err := NewAddress(fmt.Sprintf("tcp://%s?dscp=46", ln.Addr().String()))
+	require.NoError(t, err)
+
+	conn, err := dialer.Dial()
+	require.NoError(t, err)
+
+	defer conn.Close()
+
+	assert.Equal(t, 46<<2, testGetsockoptInt(t, conn.(syscall.Conn), unix.IPPROTO_IP, unix.IP_TOS))
+
+	listener, err := NewAddress("tcp6://[::1]:0?dscp=10")
+	require.NoError(t, err)
+
+	ln6, err := listener.Listener()
+	if err != nil {
+		t.Skipf("IPv6 is not available: %v", err)
+	}
+
+	defer ln6.Close()
+
+	assert.Equal(t, 10<<2, testGetsockoptInt(t, ln6.(syscall.Conn), unix.IPPROTO_IPV6, unix.IPV6_TCLASS))
+}
+
+func testGetsockoptInt(t *testing.T, conn syscall.Conn, level, opt int) (value int) {
+	raw, err := conn.SyscallConn()
+	require.NoError(t, err)
+
+	require.NoError(t, raw.Control(func(fd uintptr) {
+		value, err = unix.GetsockoptInt(int(fd), level, opt)
+	}))
+
+	require.NoError(t, err)
+
+	return value
+}
@@ -0,0 +1,19 @@
+//go:build freebsd || darwin
+
+package schema
+
+import (
+	"golang.org/x/sys/unix"
+)
+
+// fastOpenSocketOption returns the function which enables TCP Fast Open on a socket, or nil for dialers as client
+// side TCP Fast Open is not supported on this platform.
+func (a *Address) fastOpenSocketOption(listener bool) func(fd int, network string) error {
+	if !listener {
+		return nil
+	}
+
+	return func(fd int, _ string) error {
+		return unix.SetsockoptInt(fd, unix.IPPROTO_TCP, unix.TCP_FASTOPEN, 1)
+	}
+}
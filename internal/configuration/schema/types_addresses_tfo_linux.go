@@ -0,0 +1,28 @@
+package schema
+
+import (
+	"golang.org/x/sys/unix"
+)
+
+// addressFastOpenQueueLength is the maximum length of the queue of pending TCP Fast Open requests for listeners which
+// don't have a 'backlog' option.
+const addressFastOpenQueueLength = 256
+
+// fastOpenSocketOption returns the function which enables TCP Fast Open on a socket. Listeners use the 'backlog'
+// option as the maximum length of the queue of pending TCP Fast Open requests if it's set.
+func (a *Address) fastOpenSocketOption(listener bool) func(fd int, network string) error {
+	if !listener {
+		return func(fd int, _ string) error {
+			return unix.SetsockoptInt(fd, unix.IPPROTO_TCP, unix.TCP_FASTOPEN_CONNECT, 1)
+		}
+	}
+
+	qlen := a.Backlog()
+	if qlen == 0 {
+		qlen = addressFastOpenQueueLength
+	}
+
+	return func(fd int, _ string) error {
+		return unix.SetsockoptInt(fd, unix.IPPROTO_TCP, unix.TCP_FASTOPEN, qlen)
+	}
+}
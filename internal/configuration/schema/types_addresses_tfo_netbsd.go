@@ -0,0 +1,6 @@
+package schema
+
+// fastOpenSocketOption returns nil as TCP Fast Open is not supported on this platform.
+func (a *Address) fastOpenSocketOption(listener bool) func(fd int, network string) error {
+	return nil
+}
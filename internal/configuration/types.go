@@ -1,6 +1,8 @@
 package configuration
 
 import (
+	"fmt"
+
 	"github.com/knadh/koanf/v2"
 	"github.com/spf13/pflag"
 
@@ -57,6 +59,23 @@ type MapSource struct {
 	koanf *koanf.Koanf
 }
 
+// DecodeKeyError is an error which occurred decoding the value of a configuration Key. The Key is the dotted path of the
+// value including the index or key of any slice or map items, such as 'access_control.rules[0].domain_regex[1]'.
+type DecodeKeyError struct {
+	Key string
+	Err error
+}
+
+// Error implements the error interface.
+func (e *DecodeKeyError) Error() string {
+	return fmt.Sprintf("the key '%s' has an invalid value: %s", e.Key, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *DecodeKeyError) Unwrap() error {
+	return e.Err
+}
+
 // File represents a file path and data content as bytes.
 type File struct {
 	Path string
@@ -31,7 +31,7 @@ func ValidateAuthenticationBackend(config *schema.AuthenticationBackend, validat
 		}
 	}
 
-	if config.PasswordReset.CustomURL.String() != "" {
+	if config.PasswordReset.CustomURL != nil {
 		switch config.PasswordReset.CustomURL.Scheme {
 		case schemeHTTP, schemeHTTPS:
 			config.PasswordReset.Disable = false
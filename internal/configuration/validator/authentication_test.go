@@ -535,7 +535,7 @@ func (suite *FileBasedAuthenticationBackend) TestShouldSetDefaultValues() {
 }
 
 func (suite *FileBasedAuthenticationBackend) TestShouldRaiseErrorWhenResetURLIsInvalid() {
-	suite.config.PasswordReset.CustomURL = url.URL{Scheme: "ldap", Host: "google.com"}
+	suite.config.PasswordReset.CustomURL = &url.URL{Scheme: "ldap", Host: "google.com"}
 	suite.config.PasswordReset.Disable = true
 
 	suite.True(suite.config.PasswordReset.Disable)
@@ -551,7 +551,7 @@ func (suite *FileBasedAuthenticationBackend) TestShouldRaiseErrorWhenResetURLIsI
 }
 
 func (suite *FileBasedAuthenticationBackend) TestShouldNotRaiseErrorWhenResetURLIsValid() {
-	suite.config.PasswordReset.CustomURL = url.URL{Scheme: schemeHTTPS, Host: "google.com"}
+	suite.config.PasswordReset.CustomURL = &url.URL{Scheme: schemeHTTPS, Host: "google.com"}
 
 	ValidateAuthenticationBackend(&suite.config, suite.validator)
 
@@ -560,7 +560,7 @@ func (suite *FileBasedAuthenticationBackend) TestShouldNotRaiseErrorWhenResetURL
 }
 
 func (suite *FileBasedAuthenticationBackend) TestShouldConfigureDisableResetPasswordWhenCustomURL() {
-	suite.config.PasswordReset.CustomURL = url.URL{Scheme: schemeHTTPS, Host: "google.com"}
+	suite.config.PasswordReset.CustomURL = &url.URL{Scheme: schemeHTTPS, Host: "google.com"}
 	suite.config.PasswordReset.Disable = true
 
 	suite.True(suite.config.PasswordReset.Disable)
@@ -108,7 +108,8 @@ func validateDefault2FAMethod(config *schema.Configuration, validator *schema.St
 
 func NewValidateCtx() *ValidateCtx {
 	return &ValidateCtx{
-		Context: context.Background(),
+		oidcClientScopeGrantConstraints: append([]OIDCClientScopeGrantConstraint{}, defaultOIDCClientScopeGrantConstraints...),
+		Context:                         context.Background(),
 	}
 }
 
@@ -119,6 +120,8 @@ type ValidateCtx struct {
 
 	cacheSectorIdentifierURIs map[string][]string
 
+	oidcClientScopeGrantConstraints []OIDCClientScopeGrantConstraint
+
 	context.Context
 }
 
@@ -151,3 +154,18 @@ func WithTLSConfig(config *tls.Config) func(ctx *ValidateCtx) {
 		ctx.tlsconfig, ctx.client = config, nil
 	}
 }
+
+// OIDCClientScopeGrantConstraint is a cross-constraint between the scopes and grant types of an OpenID Connect 1.0
+// client. If a client is configured with any of the Scopes it must also be configured with any of the GrantTypes.
+type OIDCClientScopeGrantConstraint struct {
+	Scopes     []string
+	GrantTypes []string
+}
+
+// WithOIDCClientScopeGrantConstraints registers additional OIDCClientScopeGrantConstraint's which are enforced in
+// addition to the default constraints.
+func WithOIDCClientScopeGrantConstraints(constraints ...OIDCClientScopeGrantConstraint) func(ctx *ValidateCtx) {
+	return func(ctx *ValidateCtx) {
+		ctx.oidcClientScopeGrantConstraints = append(ctx.oidcClientScopeGrantConstraints, constraints...)
+	}
+}
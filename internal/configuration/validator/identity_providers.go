@@ -718,6 +718,7 @@ func validateOIDCClient(ctx *ValidateCtx, c int, config *schema.IdentityProvider
 	validateOIDCClientResponseTypes(c, config, validator, setDefaults, errDeprecatedFunc)
 	validateOIDCClientResponseModes(c, config, validator, setDefaults, errDeprecatedFunc)
 	validateOIDCClientGrantTypes(c, config, validator, setDefaults, errDeprecatedFunc)
+	validateOIDCClientScopeGrantConstraints(ctx, c, config, validator, errDeprecatedFunc)
 	validateOIDCClientRedirectURIs(c, config, validator, errDeprecatedFunc)
 	validateOIDCClientRequestURIs(c, config, validator)
 
@@ -1250,6 +1251,35 @@ func validateOIDCClientGrantTypesCheckRelated(c int, config *schema.IdentityProv
 	}
 }
 
+func validateOIDCClientScopeGrantConstraints(ctx *ValidateCtx, c int, config *schema.IdentityProvidersOpenIDConnect, validator *schema.StructValidator, errDeprecatedFunc func()) {
+	// The scopes of clients using the client credentials grant are validated by validateOIDCClientScopesClientCredentialsGrant.
+	if len(config.Clients[c].GrantTypes) == 0 || utils.IsStringInSlice(oidc.GrantTypeClientCredentials, config.Clients[c].GrantTypes) {
+		return
+	}
+
+	for _, constraint := range ctx.oidcClientScopeGrantConstraints {
+		if utils.IsStringSliceContainsAny(constraint.GrantTypes, config.Clients[c].GrantTypes) {
+			continue
+		}
+
+		var scopes []string
+
+		for _, scope := range constraint.Scopes {
+			if utils.IsStringInSlice(scope, config.Clients[c].Scopes) {
+				scopes = append(scopes, scope)
+			}
+		}
+
+		if len(scopes) == 0 {
+			continue
+		}
+
+		errDeprecatedFunc()
+
+		validator.PushWarning(fmt.Errorf(errFmtOIDCClientInvalidScopeGrantConstraint, config.Clients[c].ID, utils.StringJoinAnd(scopes), utils.StringJoinOr(constraint.GrantTypes), utils.StringJoinAnd(config.Clients[c].GrantTypes)))
+	}
+}
+
 func validateOIDCClientRedirectURIs(c int, config *schema.IdentityProvidersOpenIDConnect, validator *schema.StructValidator, errDeprecatedFunc func()) {
 	var (
 		parsedRedirectURI *url.URL
@@ -3364,6 +3364,103 @@ func TestValidateOIDCClients(t *testing.T) {
 	}
 }
 
+func TestValidateOIDCClientScopeGrantConstraints(t *testing.T) {
+	testCases := []struct {
+		name       string
+		opts       []func(ctx *ValidateCtx)
+		scopes     []string
+		grantTypes []string
+		warnings   []string
+	}{
+		{
+			"ShouldPassSatisfiedConstraint",
+			nil,
+			[]string{oidc.ScopeOpenID, oidc.ScopeOfflineAccess},
+			[]string{oidc.GrantTypeAuthorizationCode, oidc.GrantTypeRefreshToken},
+			nil,
+		},
+		{
+			"ShouldPassWithoutConstrainedScopes",
+			nil,
+			[]string{oidc.ScopeOpenID, oidc.ScopeProfile},
+			[]string{oidc.GrantTypeAuthorizationCode},
+			nil,
+		},
+		{
+			"ShouldPassWithoutGrantTypes",
+			nil,
+			[]string{oidc.ScopeOpenID, oidc.ScopeOfflineAccess},
+			nil,
+			nil,
+		},
+		{
+			"ShouldPassWithClientCredentialsGrant",
+			nil,
+			[]string{oidc.ScopeOfflineAccess},
+			[]string{oidc.GrantTypeClientCredentials},
+			nil,
+		},
+		{
+			"ShouldRaiseWarningOnViolatedConstraint",
+			nil,
+			[]string{oidc.ScopeOpenID, oidc.ScopeOfflineAccess},
+			[]string{oidc.GrantTypeAuthorizationCode},
+			[]string{
+				"identity_providers: oidc: clients: client 'test': option 'scopes' should only have the values 'offline_access' if the client is also configured with a 'grant_types' option such as 'refresh_token' but it only has the values 'authorization_code'",
+			},
+		},
+		{
+			"ShouldRaiseWarningOnViolatedRegisteredConstraint",
+			[]func(ctx *ValidateCtx){
+				WithOIDCClientScopeGrantConstraints(OIDCClientScopeGrantConstraint{Scopes: []string{oidc.ScopeGroups}, GrantTypes: []string{oidc.GrantTypeAuthorizationCode, oidc.GrantTypeDeviceCode}}),
+			},
+			[]string{oidc.ScopeOpenID, oidc.ScopeGroups, oidc.ScopeOffline},
+			[]string{oidc.GrantTypeImplicit},
+			[]string{
+				"identity_providers: oidc: clients: client 'test': option 'scopes' should only have the values 'offline' if the client is also configured with a 'grant_types' option such as 'refresh_token' but it only has the values 'implicit'",
+				"identity_providers: oidc: clients: client 'test': option 'scopes' should only have the values 'groups' if the client is also configured with a 'grant_types' option such as 'authorization_code' or 'urn:ietf:params:oauth:grant-type:device_code' but it only has the values 'implicit'",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := NewValidateCtx()
+
+			for _, opt := range tc.opts {
+				opt(ctx)
+			}
+
+			config := &schema.IdentityProvidersOpenIDConnect{
+				Clients: []schema.IdentityProvidersOpenIDConnectClient{
+					{
+						ID:         "test",
+						Scopes:     tc.scopes,
+						GrantTypes: tc.grantTypes,
+					},
+				},
+			}
+
+			validator := schema.NewStructValidator()
+
+			var deprecated bool
+
+			validateOIDCClientScopeGrantConstraints(ctx, 0, config, validator, func() { deprecated = true })
+
+			assert.Len(t, validator.Errors(), 0)
+			require.Len(t, validator.Warnings(), len(tc.warnings))
+
+			for i, warning := range tc.warnings {
+				assert.EqualError(t, validator.Warnings()[i], warning)
+			}
+
+			assert.Equal(t, len(tc.warnings) != 0, deprecated)
+		})
+	}
+
+	assert.Len(t, NewValidateCtx().oidcClientScopeGrantConstraints, len(defaultOIDCClientScopeGrantConstraints))
+}
+
 func TestValidateOIDCClientTokenEndpointAuthMethod(t *testing.T) {
 	testCases := []struct {
 		name     string
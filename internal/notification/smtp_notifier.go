@@ -16,7 +16,6 @@ import (
 	"github.com/authelia/authelia/v4/internal/logging"
 	"github.com/authelia/authelia/v4/internal/random"
 	"github.com/authelia/authelia/v4/internal/templates"
-	"github.com/authelia/authelia/v4/internal/utils"
 )
 
 // NewSMTPNotifier creates a SMTPNotifier using the notifier configuration.
@@ -26,7 +25,7 @@ func NewSMTPNotifier(config *schema.NotifierSMTP, certPool *x509.CertPool) *SMTP
 	var configTLS *tls.Config
 
 	if config.TLS != nil {
-		configTLS = utils.NewTLSConfig(config.TLS, certPool)
+		configTLS = schema.NewTLSConfig(config.TLS, certPool)
 	}
 
 	var opts []gomail.Option //nolint:prealloc
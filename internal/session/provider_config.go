@@ -102,7 +102,7 @@ func NewSessionProvider(config schema.Session, certPool *x509.CertPool) (name st
 		var tlsConfig *tls.Config
 
 		if config.Redis.TLS != nil {
-			tlsConfig = utils.NewTLSConfig(config.Redis.TLS, certPool)
+			tlsConfig = schema.NewTLSConfig(config.Redis.TLS, certPool)
 		}
 
 		if config.Redis.HighAvailability != nil && config.Redis.HighAvailability.SentinelName != "" {
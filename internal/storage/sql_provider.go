@@ -37,6 +37,9 @@ func NewProvider(config *schema.Configuration, caCertPool *x509.CertPool) (provi
 // NewSQLProvider generates a generic SQLProvider to be used with other SQL provider NewUp's.
 func NewSQLProvider(config *schema.Configuration, name, driverName, dataSourceName string) (provider SQLProvider) {
 	db, err := sqlx.Open(driverName, dataSourceName)
+	if err == nil {
+		db.SetConnMaxLifetime(sqlConnMaxLifetime(config, name))
+	}
 
 	provider = SQLProvider{
 		db:         &SQLXWrapDB{db},
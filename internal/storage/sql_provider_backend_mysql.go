@@ -41,7 +41,7 @@ func dsnMySQL(config *schema.StorageMySQL, caCertPool *x509.CertPool) (dataSourc
 	if config.TLS != nil {
 		dsnConfig.TLSConfig = fmt.Sprintf("authelia-%s-storage", utils.Version())
 
-		_ = mysql.RegisterTLSConfig(dsnConfig.TLSConfig, utils.NewTLSConfig(config.TLS, caCertPool))
+		_ = mysql.RegisterTLSConfig(dsnConfig.TLSConfig, schema.NewTLSConfig(config.TLS, caCertPool))
 	}
 
 	dsnConfig.DBName = config.Database
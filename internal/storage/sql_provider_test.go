@@ -51,6 +51,65 @@ func TestNewProvider(t *testing.T) {
 	}
 }
 
+func TestSQLConnMaxLifetime(t *testing.T) {
+	address, err := schema.NewAddress("tcp://db.example.com?conn_max_lifetime=30m")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		config   *schema.Configuration
+		provider string
+		expected time.Duration
+	}{
+		{
+			"ShouldReturnPostgreSQLLifetime",
+			&schema.Configuration{
+				Storage: schema.Storage{
+					PostgreSQL: &schema.StoragePostgreSQL{StorageSQL: schema.StorageSQL{Address: &schema.AddressTCP{Address: *address}}},
+				},
+			},
+			providerPostgres,
+			time.Minute * 30,
+		},
+		{
+			"ShouldReturnMySQLLifetime",
+			&schema.Configuration{
+				Storage: schema.Storage{
+					MySQL: &schema.StorageMySQL{StorageSQL: schema.StorageSQL{Address: &schema.AddressTCP{Address: *address}}},
+				},
+			},
+			providerMySQL,
+			time.Minute * 30,
+		},
+		{
+			"ShouldReturnZeroForSQLite",
+			&schema.Configuration{
+				Storage: schema.Storage{
+					Local: &schema.StorageLocal{Path: "db.sqlite3"},
+				},
+			},
+			providerSQLite,
+			0,
+		},
+		{
+			"ShouldReturnZeroForMismatchedProvider",
+			&schema.Configuration{
+				Storage: schema.Storage{
+					MySQL: &schema.StorageMySQL{StorageSQL: schema.StorageSQL{Address: &schema.AddressTCP{Address: *address}}},
+				},
+			},
+			providerPostgres,
+			0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, sqlConnMaxLifetime(tc.config, tc.provider))
+		})
+	}
+}
+
 func TestSQLProviderStartupAndClose(t *testing.T) {
 	t.Run("ShouldStartupAndClose", func(t *testing.T) {
 		provider := newTestSQLiteProvider(t)
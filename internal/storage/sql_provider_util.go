@@ -3,6 +3,9 @@ package storage
 import (
 	"database/sql"
 	"fmt"
+	"time"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
 )
 
 func checkSingleUpdateResult(result sql.Result) (err error) {
@@ -19,3 +22,16 @@ func checkSingleUpdateResult(result sql.Result) (err error) {
 		return nil
 	}
 }
+
+// sqlConnMaxLifetime returns the maximum amount of time a pooled connection may be reused for the named provider from
+// the 'conn_max_lifetime' option of the configured address, or 0 if connections may be reused indefinitely.
+func sqlConnMaxLifetime(config *schema.Configuration, name string) time.Duration {
+	switch {
+	case name == providerPostgres && config.Storage.PostgreSQL != nil && config.Storage.PostgreSQL.Address != nil:
+		return config.Storage.PostgreSQL.Address.ConnMaxLifetime()
+	case name == providerMySQL && config.Storage.MySQL != nil && config.Storage.MySQL.Address != nil:
+		return config.Storage.MySQL.Address.ConnMaxLifetime()
+	default:
+		return 0
+	}
+}
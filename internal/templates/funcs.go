@@ -152,7 +152,7 @@ func FuncGetEnv(key string) string {
 		return key
 	}
 
-	if isSecretEnvKey(key) {
+	if IsSecretEnvKey(key) {
 		return ""
 	}
 
@@ -173,7 +173,7 @@ func FuncMustGetEnv(key string) (string, error) {
 		return "", fmt.Errorf("environment variable '%s' isn't set", key)
 	}
 
-	if isSecretEnvKey(key) {
+	if IsSecretEnvKey(key) {
 		return "", nil
 	}
 
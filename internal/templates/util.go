@@ -23,7 +23,8 @@ var envSecretSuffixes = []string{
 	"KEY", "SECRET", "PASSWORD", "TOKEN", "CERTIFICATE_CHAIN",
 }
 
-func isSecretEnvKey(key string) (isSecretEnvKey bool) {
+// IsSecretEnvKey returns true if the key is an environment variable name which may contain a secret.
+func IsSecretEnvKey(key string) (isSecretEnvKey bool) {
 	key = strings.ToUpper(key)
 
 	if !strings.HasPrefix(key, envPrefix) && !strings.HasPrefix(key, envXPrefix) {
@@ -30,7 +30,7 @@ func TestIsSecretEnvKey(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			for _, env := range tc.have {
 				t.Run(env, func(t *testing.T) {
-					assert.Equal(t, tc.expected, isSecretEnvKey(env))
+					assert.Equal(t, tc.expected, IsSecretEnvKey(env))
 				})
 			}
 		})
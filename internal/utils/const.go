@@ -28,6 +28,7 @@ const (
 	BlockTypeRSAPublicKey       = "RSA PUBLIC KEY"
 	BlockTypeECDSAPrivateKey    = "EC PRIVATE KEY"
 	BlockTypePKCS8PrivateKey    = "PRIVATE KEY"
+	BlockTypeEncryptedPKCS8Key  = "ENCRYPTED PRIVATE KEY"
 	BlockTypePKIXPublicKey      = "PUBLIC KEY"
 	BlockTypeCertificate        = "CERTIFICATE"
 	BlockTypeCertificateRequest = "CERTIFICATE REQUEST"
@@ -84,6 +85,18 @@ var (
 
 	standardDurationUnits = []string{"ns", "us", "µs", "μs", "ms", "s", "m", "h"}
 
+	durationFormatUnits = []struct {
+		name     string
+		duration time.Duration
+	}{
+		{"h", time.Hour},
+		{"m", time.Minute},
+		{"s", time.Second},
+		{"ms", time.Millisecond},
+		{"us", time.Microsecond},
+		{"ns", time.Nanosecond},
+	}
+
 	reOnlyNumeric      = regexp.MustCompile(`^\d+$`)
 	reDurationStandard = regexp.MustCompile(`(?P<Duration>[1-9]\d*?)(?P<Unit>[^\d\s]+)`)
 	reNumeric          = regexp.MustCompile(`\d+`)
@@ -122,6 +135,14 @@ var htmlEscaper = strings.NewReplacer(
 // ErrTimeoutReached error thrown when a timeout is reached.
 var ErrTimeoutReached = errors.New("timeout reached")
 
+var (
+	// ErrPEMPassphraseIncorrect is returned when an encrypted PEM block could not be decrypted with the passphrase.
+	ErrPEMPassphraseIncorrect = errors.New("the passphrase is incorrect")
+
+	// ErrPEMPassphraseRequired is returned when an encrypted PEM block is parsed without a passphrase.
+	ErrPEMPassphraseRequired = errors.New("the PEM block is encrypted but no passphrase was provided")
+)
+
 const (
 	windows               = "windows"
 	errFmtLinuxNotFound   = "%s %%s: no such file or directory"
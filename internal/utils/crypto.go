@@ -23,8 +23,7 @@ import (
 	"strings"
 	"time"
 
-	"github.com/authelia/authelia/v4/internal/configuration/schema"
-	"github.com/authelia/authelia/v4/internal/logging"
+	"github.com/sirupsen/logrus"
 )
 
 // PEMBlockType represent an enum of the existing PEM block types.
@@ -202,6 +201,44 @@ func ParseX509FromPEM(data []byte) (key any, err error) {
 	return ParsePEMBlock(block)
 }
 
+// ParseX509FromPEMWithPassphrase parses PEM bytes and returns a PKCS key in the same way as ParseX509FromPEM, except
+// that an encrypted PKCS #8 private key is decrypted with the passphrase before it's parsed. If the passphrase is
+// incorrect the returned error wraps ErrPEMPassphraseIncorrect.
+func ParseX509FromPEMWithPassphrase(data, passphrase []byte) (key any, err error) {
+	var (
+		block *pem.Block
+		rest  []byte
+	)
+
+	if block, rest = pem.Decode(data); block == nil {
+		return nil, errors.New("error occurred attempting to parse PEM block: either no PEM block was supplied or it was malformed")
+	}
+
+	if len(rest) != 0 {
+		return nil, errors.New("error occurred attempting to parse PEM block: the block either had trailing data or was otherwise malformed")
+	}
+
+	if block.Type != BlockTypeEncryptedPKCS8Key {
+		return ParsePEMBlock(block)
+	}
+
+	if len(passphrase) == 0 {
+		return nil, fmt.Errorf("error occurred attempting to decrypt PEM block: %w", ErrPEMPassphraseRequired)
+	}
+
+	var decrypted []byte
+
+	if decrypted, err = DecryptPKCS8PrivateKey(block.Bytes, passphrase); err != nil {
+		return nil, fmt.Errorf("error occurred attempting to decrypt PEM block: %w", err)
+	}
+
+	if key, err = x509.ParsePKCS8PrivateKey(decrypted); err != nil {
+		return nil, fmt.Errorf("error occurred attempting to decrypt PEM block: %w", ErrPEMPassphraseIncorrect)
+	}
+
+	return key, nil
+}
+
 // ParseX509FromPEMRecursive allows returning the appropriate key type given some PEM encoded input.
 // For Keys this is a single value of one of *rsa.PrivateKey, *rsa.PublicKey, *ecdsa.PrivateKey, *ecdsa.PublicKey,
 // ed25519.PrivateKey, or ed25519.PublicKey. For certificates this is
@@ -312,34 +349,6 @@ func IsX509PrivateKey(i any) bool {
 	}
 }
 
-// NewTLSConfig generates a tls.Config from a schema.TLS and a x509.CertPool.
-func NewTLSConfig(config *schema.TLS, rootCAs *x509.CertPool) (tlsConfig *tls.Config) {
-	if config == nil {
-		return nil
-	}
-
-	var certificates []tls.Certificate
-
-	if config.PrivateKey != nil && config.CertificateChain.HasCertificates() {
-		certificates = []tls.Certificate{
-			{
-				Certificate: config.CertificateChain.CertificatesRaw(),
-				Leaf:        config.CertificateChain.Leaf(),
-				PrivateKey:  config.PrivateKey,
-			},
-		}
-	}
-
-	return &tls.Config{
-		ServerName:         config.ServerName,
-		InsecureSkipVerify: config.SkipVerify, //nolint:gosec // Informed choice by user. Off by default.
-		MinVersion:         config.MinimumVersion.MinVersion(),
-		MaxVersion:         config.MaximumVersion.MaxVersion(),
-		RootCAs:            rootCAs,
-		Certificates:       certificates,
-	}
-}
-
 // NewX509CertPool generates a x509.CertPool from the system PKI and the directory specified using the standard factory.
 func NewX509CertPool(directory string) (certPool *x509.CertPool, warnings []error, errors []error) {
 	return NewX509CertPoolWithFactory(directory, &StandardX509SystemCertPoolFactory{})
@@ -358,7 +367,7 @@ func NewX509CertPoolWithFactory(directory string, factory X509SystemCertPoolFact
 		certPool = x509.NewCertPool()
 	}
 
-	log := logging.Logger()
+	log := logrus.StandardLogger()
 
 	log.Tracef("Starting scan of directory %s for certificates", directory)
 
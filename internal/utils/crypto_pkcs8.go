@@ -0,0 +1,169 @@
+package utils
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/sha1" //nolint:gosec // Required for the default PBKDF2 PRF of PKCS #5.
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"hash"
+)
+
+var (
+	oidPBES2  = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 13}
+	oidPBKDF2 = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 12}
+
+	oidHMACWithSHA1   = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 7}
+	oidHMACWithSHA224 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 8}
+	oidHMACWithSHA256 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 9}
+	oidHMACWithSHA384 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 10}
+	oidHMACWithSHA512 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 11}
+
+	oidAES128CBC = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 2}
+	oidAES192CBC = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 22}
+	oidAES256CBC = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 42}
+)
+
+// encryptedPrivateKeyInfo is the EncryptedPrivateKeyInfo structure from RFC5208 section 6.
+type encryptedPrivateKeyInfo struct {
+	EncryptionAlgorithm pkix.AlgorithmIdentifier
+	EncryptedData       []byte
+}
+
+// pbes2Params is the PBES2-params structure from RFC8018 appendix A.4.
+type pbes2Params struct {
+	KeyDerivationFunc pkix.AlgorithmIdentifier
+	EncryptionScheme  pkix.AlgorithmIdentifier
+}
+
+// pbkdf2Params is the PBKDF2-params structure from RFC8018 appendix A.2.
+type pbkdf2Params struct {
+	Salt           []byte
+	IterationCount int
+	KeyLength      int                      `asn1:"optional"`
+	PRF            pkix.AlgorithmIdentifier `asn1:"optional"`
+}
+
+// DecryptPKCS8PrivateKey decrypts the DER bytes of a PKCS #8 EncryptedPrivateKeyInfo which uses the PBES2 encryption
+// scheme with PBKDF2 and AES-CBC, returning the DER bytes of the PKCS #8 PrivateKeyInfo. If the passphrase is incorrect
+// the error is ErrPEMPassphraseIncorrect, otherwise any other error indicates the data was malformed or unsupported.
+func DecryptPKCS8PrivateKey(der, passphrase []byte) (decrypted []byte, err error) {
+	var info encryptedPrivateKeyInfo
+
+	if _, err = asn1.Unmarshal(der, &info); err != nil {
+		return nil, fmt.Errorf("the encrypted private key is malformed: %w", err)
+	}
+
+	if !info.EncryptionAlgorithm.Algorithm.Equal(oidPBES2) {
+		return nil, fmt.Errorf("the encrypted private key uses the unsupported encryption algorithm '%s'", info.EncryptionAlgorithm.Algorithm)
+	}
+
+	var params pbes2Params
+
+	if _, err = asn1.Unmarshal(info.EncryptionAlgorithm.Parameters.FullBytes, &params); err != nil {
+		return nil, fmt.Errorf("the encrypted private key has malformed PBES2 parameters: %w", err)
+	}
+
+	var keyLength int
+
+	switch {
+	case params.EncryptionScheme.Algorithm.Equal(oidAES128CBC):
+		keyLength = 16
+	case params.EncryptionScheme.Algorithm.Equal(oidAES192CBC):
+		keyLength = 24
+	case params.EncryptionScheme.Algorithm.Equal(oidAES256CBC):
+		keyLength = 32
+	default:
+		return nil, fmt.Errorf("the encrypted private key uses the unsupported encryption scheme '%s'", params.EncryptionScheme.Algorithm)
+	}
+
+	var key []byte
+
+	if key, err = pkcs8DeriveKey(params.KeyDerivationFunc, passphrase, keyLength); err != nil {
+		return nil, err
+	}
+
+	var iv []byte
+
+	if _, err = asn1.Unmarshal(params.EncryptionScheme.Parameters.FullBytes, &iv); err != nil {
+		return nil, fmt.Errorf("the encrypted private key has a malformed initialization vector: %w", err)
+	}
+
+	if len(iv) != aes.BlockSize {
+		return nil, fmt.Errorf("the encrypted private key has an initialization vector with a length of %d but it must have a length of %d", len(iv), aes.BlockSize)
+	}
+
+	if len(info.EncryptedData) == 0 || len(info.EncryptedData)%aes.BlockSize != 0 {
+		return nil, errors.New("the encrypted private key has encrypted data which is not a multiple of the block size")
+	}
+
+	var block cipher.Block
+
+	if block, err = aes.NewCipher(key); err != nil {
+		return nil, err
+	}
+
+	decrypted = make([]byte, len(info.EncryptedData))
+
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(decrypted, info.EncryptedData)
+
+	return pkcs8Unpad(decrypted)
+}
+
+func pkcs8DeriveKey(kdf pkix.AlgorithmIdentifier, passphrase []byte, keyLength int) (key []byte, err error) {
+	if !kdf.Algorithm.Equal(oidPBKDF2) {
+		return nil, fmt.Errorf("the encrypted private key uses the unsupported key derivation function '%s'", kdf.Algorithm)
+	}
+
+	var params pbkdf2Params
+
+	if _, err = asn1.Unmarshal(kdf.Parameters.FullBytes, &params); err != nil {
+		return nil, fmt.Errorf("the encrypted private key has malformed PBKDF2 parameters: %w", err)
+	}
+
+	if params.KeyLength != 0 && params.KeyLength != keyLength {
+		return nil, fmt.Errorf("the encrypted private key has a PBKDF2 key length of %d but the encryption scheme requires a key length of %d", params.KeyLength, keyLength)
+	}
+
+	var h func() hash.Hash
+
+	switch {
+	case len(params.PRF.Algorithm) == 0, params.PRF.Algorithm.Equal(oidHMACWithSHA1):
+		h = sha1.New
+	case params.PRF.Algorithm.Equal(oidHMACWithSHA224):
+		h = sha256.New224
+	case params.PRF.Algorithm.Equal(oidHMACWithSHA256):
+		h = sha256.New
+	case params.PRF.Algorithm.Equal(oidHMACWithSHA384):
+		h = sha512.New384
+	case params.PRF.Algorithm.Equal(oidHMACWithSHA512):
+		h = sha512.New
+	default:
+		return nil, fmt.Errorf("the encrypted private key uses the unsupported PBKDF2 pseudorandom function '%s'", params.PRF.Algorithm)
+	}
+
+	return pbkdf2.Key(h, string(passphrase), params.Salt, params.IterationCount, keyLength)
+}
+
+// pkcs8Unpad removes the RFC8018 section 6.1.1 padding. As the padding is the only integrity check performed by the
+// encryption scheme invalid padding almost always indicates an incorrect passphrase.
+func pkcs8Unpad(data []byte) ([]byte, error) {
+	n := int(data[len(data)-1])
+
+	if n == 0 || n > aes.BlockSize || n > len(data) {
+		return nil, ErrPEMPassphraseIncorrect
+	}
+
+	for _, b := range data[len(data)-n:] {
+		if int(b) != n {
+			return nil, ErrPEMPassphraseIncorrect
+		}
+	}
+
+	return data[:len(data)-n], nil
+}
@@ -19,8 +19,6 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-
-	"github.com/authelia/authelia/v4/internal/configuration/schema"
 )
 
 func TestShouldReturnErrorWhenX509CertPoolFactoryNotProvided(t *testing.T) {
@@ -349,6 +347,15 @@ func TestShouldParseCurves(t *testing.T) {
 	}
 }
 
+func testMustReadCryptoFile(name string) []byte {
+	data, err := os.ReadFile(filepath.Join("..", "configuration", "test_resources", "crypto", name))
+	if err != nil {
+		panic(err)
+	}
+
+	return data
+}
+
 func testMustBuildPrivateKey(b PrivateKeyBuilder) any {
 	k, err := b.Build()
 	if err != nil {
@@ -787,6 +794,84 @@ func TestParseX509FromPEM(t *testing.T) {
 	}
 }
 
+func TestParseX509FromPEMWithPassphrase(t *testing.T) {
+	testCases := []struct {
+		name       string
+		have       []byte
+		passphrase string
+		expected   string
+		err        string
+	}{
+		{
+			"ShouldDecryptECDSA",
+			testMustReadCryptoFile("ecdsa.P256.encrypted.pem"),
+			"authelia",
+			"ecdsa.P256.pem",
+			"",
+		},
+		{
+			"ShouldDecryptRSA",
+			testMustReadCryptoFile("rsa.2048.encrypted.pem"),
+			"authelia",
+			"rsa.2048.pem",
+			"",
+		},
+		{
+			"ShouldParseUnencryptedWithPassphrase",
+			testMustReadCryptoFile("rsa.2048.pem"),
+			"authelia",
+			"rsa.2048.pem",
+			"",
+		},
+		{
+			"ShouldErrorWrongPassphrase",
+			testMustReadCryptoFile("ecdsa.P256.encrypted.pem"),
+			"wrong",
+			"",
+			"error occurred attempting to decrypt PEM block: the passphrase is incorrect",
+		},
+		{
+			"ShouldErrorNoPassphrase",
+			testMustReadCryptoFile("rsa.2048.encrypted.pem"),
+			"",
+			"",
+			"error occurred attempting to decrypt PEM block: the PEM block is encrypted but no passphrase was provided",
+		},
+		{
+			"ShouldErrorMalformed",
+			pem.EncodeToMemory(&pem.Block{Type: BlockTypeEncryptedPKCS8Key, Bytes: []byte{0x30}}),
+			"authelia",
+			"",
+			"error occurred attempting to decrypt PEM block: the encrypted private key is malformed: asn1: syntax error: truncated tag or length",
+		},
+		{
+			"ShouldErrorNotPEM",
+			[]byte("not pem"),
+			"authelia",
+			"",
+			"error occurred attempting to parse PEM block: either no PEM block was supplied or it was malformed",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			key, err := ParseX509FromPEMWithPassphrase(tc.have, []byte(tc.passphrase))
+
+			if tc.err == "" {
+				require.NoError(t, err)
+
+				expected, err := ParseX509FromPEM(testMustReadCryptoFile(tc.expected))
+				require.NoError(t, err)
+
+				assert.Equal(t, expected, key)
+			} else {
+				assert.EqualError(t, err, tc.err)
+				assert.Nil(t, key)
+			}
+		})
+	}
+}
+
 func TestAssertToX509Certificate(t *testing.T) {
 	testCases := []struct {
 		name   string
@@ -937,75 +1022,6 @@ func TestParseX509FromPEMRecursive(t *testing.T) {
 	}
 }
 
-func TestNewTLSConfig(t *testing.T) {
-	sys, err := x509.SystemCertPool()
-	require.NoError(t, err)
-
-	rawKey, err := os.ReadFile(filepath.Join("..", "configuration", "test_resources", "crypto", "rsa.2048.pem"))
-	require.NoError(t, err)
-
-	keyAny, err := ParseX509FromPEM(rawKey)
-	require.NoError(t, err)
-
-	key, ok := keyAny.(*rsa.PrivateKey)
-	require.True(t, ok)
-
-	rawCert, err := os.ReadFile(filepath.Join("..", "configuration", "test_resources", "crypto", "rsa.2048.crt"))
-	require.NoError(t, err)
-
-	chain, err := schema.NewX509CertificateChain(string(rawCert))
-	require.NoError(t, err)
-
-	testCases := []struct {
-		name   string
-		have   *schema.TLS
-		pool   *x509.CertPool
-		expect bool
-	}{
-		{
-			"ShouldHandleNil",
-			nil,
-			nil,
-			false,
-		},
-		{
-			"ShouldHandleStandard",
-			&schema.TLS{},
-			sys,
-			true,
-		},
-		{
-			"ShouldHandleKeySolo",
-			&schema.TLS{
-				PrivateKey: key,
-			},
-			sys,
-			true,
-		},
-		{
-			"ShouldHandleKey",
-			&schema.TLS{
-				PrivateKey:       key,
-				CertificateChain: *chain,
-			},
-			sys,
-			true,
-		},
-	}
-
-	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			actual := NewTLSConfig(tc.have, tc.pool)
-
-			if tc.expect {
-				assert.NotNil(t, actual)
-			} else {
-				assert.Nil(t, actual)
-			}
-		})
-	}
-}
-
 func TestIsInsecureCipherSuite(t *testing.T) {
 	testCases := []struct {
 		name   string
@@ -1,15 +1,78 @@
 package utils
 
 import (
+	"fmt"
 	"net"
+	"strconv"
 	"strings"
 )
 
 // ParseHostCIDR parses a raw string as a *net.IPNet similar to net.ParseCIDR, in fact it leverages it. The only
 // differences between the functions is if the input does not contain a single '/' it first parses it with net.ParseIP
 // to determine if it's a IPv4 or IPv6 and then adds the relevant CIDR suffix for a single host, and it only returns the
-// *net.IPNet and error, discarding the net.IP.
+// *net.IPNet and error, discarding the net.IP. The IPv6 zone identifier is discarded if present, see ParseHostCIDRZone.
 func ParseHostCIDR(s string) (cidr *net.IPNet, err error) {
+	cidr, _, err = ParseHostCIDRZone(s)
+
+	return cidr, err
+}
+
+// ParseHostCIDRZone is the same as ParseHostCIDR except that it also returns the zone identifier of an IPv6 address
+// such as the 'eth0' of 'fe80::1%eth0/64', which is removed from the address before it's parsed. The zone is empty if
+// the address doesn't have a zone identifier.
+func ParseHostCIDRZone(s string) (cidr *net.IPNet, zone string, err error) {
+	var zoned bool
+
+	addr, suffix, found := strings.Cut(s, "/")
+
+	if addr, zone, zoned = strings.Cut(addr, "%"); zoned && (zone == "" || !strings.Contains(addr, ":")) {
+		return nil, "", &net.ParseError{Type: "CIDR address", Text: s}
+	}
+
+	if found {
+		addr += "/" + suffix
+	}
+
+	if cidr, err = parseHostCIDR(addr); err != nil {
+		return nil, "", &net.ParseError{Type: "CIDR address", Text: s}
+	}
+
+	return cidr, zone, nil
+}
+
+// FormatHostCIDRZone returns the string representation of a *net.IPNet with the IPv6 zone identifier, which is the
+// inverse of ParseHostCIDRZone. If the zone is empty this is the same as the String func of the *net.IPNet.
+func FormatHostCIDRZone(cidr *net.IPNet, zone string) string {
+	if cidr == nil {
+		return "<nil>"
+	}
+
+	if zone == "" {
+		return cidr.String()
+	}
+
+	ones, _ := cidr.Mask.Size()
+
+	return fmt.Sprintf("%s%%%s/%d", cidr.IP, zone, ones)
+}
+
+// ValidateIPZone returns an error if the IPv6 zone identifier is not the name or index of a network interface on this
+// host.
+func ValidateIPZone(zone string) (err error) {
+	if index, errIndex := strconv.Atoi(zone); errIndex == nil {
+		_, err = net.InterfaceByIndex(index)
+	} else {
+		_, err = net.InterfaceByName(zone)
+	}
+
+	if err != nil {
+		return fmt.Errorf("the zone '%s' is not the name or index of a network interface on this host: %w", zone, err)
+	}
+
+	return nil
+}
+
+func parseHostCIDR(s string) (cidr *net.IPNet, err error) {
 	switch strings.Count(s, "/") {
 	case 1:
 		_, cidr, err = net.ParseCIDR(s)
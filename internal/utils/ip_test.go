@@ -2,6 +2,7 @@ package utils
 
 import (
 	"net"
+	"strconv"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -112,6 +113,12 @@ func TestParseHostCIDR(t *testing.T) {
 			nil,
 			"invalid CIDR address: 192.168.1.1/33",
 		},
+		{
+			"ShouldParseIPv6WithZoneDiscardingZone",
+			"fe80::1%eth0/64",
+			mustParse("fe80::/64"),
+			"",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -126,3 +133,126 @@ func TestParseHostCIDR(t *testing.T) {
 		})
 	}
 }
+
+func TestParseHostCIDRZone(t *testing.T) {
+	mustParse := func(in string) *net.IPNet {
+		_, out, err := net.ParseCIDR(in)
+		require.NoError(t, err)
+
+		return out
+	}
+
+	testCases := []struct {
+		name         string
+		have         string
+		expected     *net.IPNet
+		expectedZone string
+		formatted    string
+		err          string
+	}{
+		{
+			"ShouldParseIPv6WithZone",
+			"fe80::1%eth0",
+			mustParse("fe80::1/128"),
+			"eth0",
+			"fe80::1%eth0/128",
+			"",
+		},
+		{
+			"ShouldParseIPv6WithZoneAndCIDR",
+			"fe80::1%eth0/64",
+			mustParse("fe80::/64"),
+			"eth0",
+			"fe80::%eth0/64",
+			"",
+		},
+		{
+			"ShouldParseIPv6WithNumericZone",
+			"fe80::1%2/64",
+			mustParse("fe80::/64"),
+			"2",
+			"fe80::%2/64",
+			"",
+		},
+		{
+			"ShouldParseIPv6WithoutZone",
+			"2001:db8::1/64",
+			mustParse("2001:db8::/64"),
+			"",
+			"2001:db8::/64",
+			"",
+		},
+		{
+			"ShouldParseIPv4WithoutZone",
+			"192.168.1.1",
+			mustParse("192.168.1.1/32"),
+			"",
+			"192.168.1.1/32",
+			"",
+		},
+		{
+			"ShouldNotParseIPv6WithEmptyZone",
+			"fe80::1%/64",
+			nil,
+			"",
+			"",
+			"invalid CIDR address: fe80::1%/64",
+		},
+		{
+			"ShouldNotParseIPv4WithZone",
+			"192.168.1.1%eth0/24",
+			nil,
+			"",
+			"",
+			"invalid CIDR address: 192.168.1.1%eth0/24",
+		},
+		{
+			"ShouldNotParseIPv6WithZoneInvalidCIDR",
+			"fe80::1%eth0/129",
+			nil,
+			"",
+			"",
+			"invalid CIDR address: fe80::1%eth0/129",
+		},
+		{
+			"ShouldNotParseZoneAfterCIDR",
+			"fe80::1/64%eth0",
+			nil,
+			"",
+			"",
+			"invalid CIDR address: fe80::1/64%eth0",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, zone, err := ParseHostCIDRZone(tc.have)
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+				assert.Nil(t, actual)
+				assert.Equal(t, "", zone)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.expected, actual)
+				assert.Equal(t, tc.expectedZone, zone)
+				assert.Equal(t, tc.formatted, FormatHostCIDRZone(actual, zone))
+			}
+		})
+	}
+}
+
+func TestFormatHostCIDRZoneNil(t *testing.T) {
+	assert.Equal(t, "<nil>", FormatHostCIDRZone(nil, "eth0"))
+}
+
+func TestValidateIPZone(t *testing.T) {
+	interfaces, err := net.Interfaces()
+	require.NoError(t, err)
+
+	for _, iface := range interfaces {
+		assert.NoError(t, ValidateIPZone(iface.Name))
+		assert.NoError(t, ValidateIPZone(strconv.Itoa(iface.Index)))
+	}
+
+	assert.ErrorContains(t, ValidateIPZone("authelia-not-an-interface0"), "the zone 'authelia-not-an-interface0' is not the name or index of a network interface on this host: ")
+}
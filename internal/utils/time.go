@@ -102,6 +102,31 @@ func ParseDurationString(input string) (duration time.Duration, err error) {
 	return time.ParseDuration(out)
 }
 
+// FormatDurationString formats a time.Duration as the canonical duration string which is the inverse of
+// ParseDurationString. Unlike time.Duration's String func each unit has an integer quantity and units with a quantity of
+// zero are omitted, for example 90 minutes is '1h30m' and 1.5 seconds is '1s500ms'. A zero duration is '0'. A negative
+// duration is prefixed with '-', however ParseDurationString discards the sign so only durations which are not negative
+// can be parsed back to the same value.
+func FormatDurationString(duration time.Duration) (output string) {
+	if duration == 0 {
+		return "0"
+	}
+
+	if duration < 0 {
+		output, duration = "-", -duration
+	}
+
+	for _, unit := range durationFormatUnits {
+		if qty := duration / unit.duration; qty != 0 {
+			output += fmt.Sprintf("%d%s", qty, unit.name)
+
+			duration -= qty * unit.duration
+		}
+	}
+
+	return output
+}
+
 // ParseTimeString attempts to parse a string with several time formats.
 func ParseTimeString(input string) (t time.Time, err error) {
 	return ParseTimeStringWithLayouts(input, StandardTimeLayouts)
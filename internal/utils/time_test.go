@@ -126,6 +126,37 @@ func TestStandardizeDurationString(t *testing.T) {
 	assert.Equal(t, "1h20m", actual)
 }
 
+func TestFormatDurationString(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     time.Duration
+		expected string
+	}{
+		{"ShouldFormatZero", 0, "0"},
+		{"ShouldFormatHours", time.Hour * 24, "24h"},
+		{"ShouldFormatMultipleUnits", time.Hour + time.Minute*30, "1h30m"},
+		{"ShouldFormatFractionalSeconds", time.Millisecond * 1500, "1s500ms"},
+		{"ShouldFormatAllUnits", time.Hour + time.Minute + time.Second + time.Millisecond + time.Microsecond + time.Nanosecond, "1h1m1s1ms1us1ns"},
+		{"ShouldFormatNegative", -time.Minute * 5, "-5m"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, FormatDurationString(tc.have))
+
+			actual, err := ParseDurationString(tc.expected)
+
+			assert.NoError(t, err)
+
+			if tc.have >= 0 {
+				assert.Equal(t, tc.have, actual)
+			} else {
+				assert.Equal(t, -tc.have, actual)
+			}
+		})
+	}
+}
+
 func TestParseDurationString_ShouldNotParseDurationStringWithOutOfOrderQuantitiesAndUnits(t *testing.T) {
 	duration, err := ParseDurationString("h1")
 